@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.Assert(t, Confirm(strings.NewReader("y\n"), out, "Empty the trash?"))
+	assert.Equal(t, out.String(), "Empty the trash? [y/N] ")
+
+	assert.Assert(t, Confirm(strings.NewReader(" Yes"), out, "?"), "without a newline")
+	assert.Assert(t, !Confirm(strings.NewReader("\n"), out, "?"), "no is the default")
+	assert.Assert(t, !Confirm(strings.NewReader("nope\n"), out, "?"))
+	assert.Assert(t, !Confirm(strings.NewReader(""), out, "?"), "no input")
+}
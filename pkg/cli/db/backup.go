@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/server/backup"
+	"github.com/joaorufino/pomo/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// NewDBBackupCommand returns a cobra command for `db backup`
+func NewDBBackupCommand(pomoCli cli.Cli) *cobra.Command {
+	var dir string
+
+	dbBackupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "back up the database",
+		Long:  `copy the database to a file named after the current time, it is safe while the server is running`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if dir == "" {
+				dir = pomoCli.Config().Backup.Dir
+			}
+			maybe(backupDB(dir), pomoCli.Logger())
+		},
+	}
+
+	flags := dbBackupCmd.Flags()
+	flags.StringVarP(&dir, "output", "o", "", "directory of the backup, backup.dir by default")
+
+	return dbBackupCmd
+}
+
+func backupDB(dir string) error {
+	db, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	path, err := backup.Create(context.Background(), db, dir, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// NewDBCheckCommand returns a cobra command for `db check`
+func NewDBCheckCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "check the database",
+		Long:  `run the integrity check of the database and look for pomodoros whose task does not exist`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(checkDB(pomoCli.Config().Server.DatetimeFormat), pomoCli.Logger())
+		},
+	}
+}
+
+func checkDB(datetimeFormat string) error {
+	db, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	check, err := db.Check(context.Background())
+	if err != nil {
+		return err
+	}
+	printCheck(os.Stdout, datetimeFormat, *check)
+	if !check.OK() {
+		return errors.New("the database check failed")
+	}
+	return nil
+}
+
+// printCheck prints the problems found, eg:
+// integrity: row 3 missing from index sqlite_autoindex_tag_1
+// orphaned pomodoro 7: task 2, 2021-01-16 19:05 (25m0s)
+func printCheck(w io.Writer, datetimeFormat string, check models.DatabaseCheck) {
+	if check.OK() {
+		fmt.Fprintln(w, "ok")
+		return
+	}
+	for _, problem := range check.Integrity {
+		fmt.Fprintf(w, "integrity: %s\n", problem)
+	}
+	for _, pomodoro := range check.OrphanedPomodoros {
+		fmt.Fprintf(w, "orphaned pomodoro %d: task %d, %s (%s)\n", pomodoro.ID, pomodoro.TaskID, pomodoro.Start.Format(datetimeFormat), pomodoro.Duration())
+	}
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintCheck(t *testing.T) {
+	buf := &bytes.Buffer{}
+	printCheck(buf, "2006-01-02 15:04", models.DatabaseCheck{})
+	assert.Equal(t, buf.String(), "ok\n")
+
+	start := time.Date(2021, 1, 16, 19, 5, 0, 0, time.UTC)
+	buf.Reset()
+	printCheck(buf, "2006-01-02 15:04", models.DatabaseCheck{
+		Integrity:         []string{"row 3 missing from index sqlite_autoindex_tag_1"},
+		OrphanedPomodoros: []*models.Pomodoro{{ID: 7, TaskID: 2, Start: start, End: start.Add(25 * time.Minute)}},
+	})
+	assert.Equal(t, buf.String(), "integrity: row 3 missing from index sqlite_autoindex_tag_1\norphaned pomodoro 7: task 2, 2021-01-16 19:05 (25m0s)\n")
+}
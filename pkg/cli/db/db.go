@@ -0,0 +1,43 @@
+package db
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// db command
+//
+//	pomo
+//	 ├── db
+//	 │   ├── backup
+//	 │   ├── check
+//	 │   ├── restore
+//	 │   └── vacuum
+//
+// /
+// NewDBCommand returns a cobra command for `db` subcommands
+func NewDBCommand(pomoCli cli.Cli) *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "maintenance of the database",
+		Long:  "back up, restore, compact and check the database, the server also backs it up every backup.interval",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	dbCmd.AddCommand(
+		NewDBBackupCommand(pomoCli),
+		NewDBCheckCommand(pomoCli),
+		NewDBRestoreCommand(pomoCli),
+		NewDBVacuumCommand(pomoCli),
+	)
+	return dbCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
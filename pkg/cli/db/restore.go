@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/server/backup"
+	"github.com/joaorufino/pomo/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// NewDBRestoreCommand returns a cobra command for `db restore`
+func NewDBRestoreCommand(pomoCli cli.Cli) *cobra.Command {
+	var force bool
+
+	dbRestoreCmd := &cobra.Command{
+		Use:   "restore [FILE]",
+		Short: "restore a backup of the database",
+		Long:  `replace the database with the backup in FILE, or with the latest one of backup.dir, the current database is backed up first`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := pomoCli.Config().Backup.Dir
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			} else {
+				files, err := backup.List(dir)
+				maybe(err, pomoCli.Logger())
+				if len(files) == 0 {
+					maybe(fmt.Errorf("no backup in %s", dir), pomoCli.Logger())
+				}
+				path = files[len(files)-1].Path
+			}
+			if force || cli.Confirm(os.Stdin, os.Stdout, fmt.Sprintf("Replace the database with %s?", path)) {
+				maybe(restoreDB(dir, path), pomoCli.Logger())
+			}
+		},
+	}
+
+	flags := dbRestoreCmd.Flags()
+	flags.BoolVarP(&force, "force", "f", false, "do not ask for confirmation")
+
+	return dbRestoreCmd
+}
+
+func restoreDB(dir, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ctx := context.Background()
+	previous, err := backup.Create(ctx, db, dir, time.Now())
+	if err != nil {
+		return errors.New("could not back up the current database: " + err.Error())
+	}
+	fmt.Printf("The current database was backed up to %s\n", previous)
+	return db.Restore(ctx, path)
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// NewDBVacuumCommand returns a cobra command for `db vacuum`
+func NewDBVacuumCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vacuum",
+		Short: "compact the database",
+		Long:  `rebuild the database file to reclaim the space left by deleted tasks`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(vacuumDB(), pomoCli.Logger())
+		},
+	}
+}
+
+func vacuumDB() error {
+	db, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Vacuum(context.Background())
+}
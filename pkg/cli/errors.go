@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// errorHints explain the typed errors whose message
+// is meant for developers rather than users
+var errorHints = map[models.ErrorType]string{
+	models.ErrorTypeDuplicate:   "it already exists",
+	models.ErrorTypeForeignKey:  "it refers to something that does not exist",
+	models.ErrorTypeUnavailable: "cannot reach the pomo server, is it running?",
+	models.ErrorTypeQuery:       "the pomo database failed",
+	models.ErrorTypeInternal:    "the pomo server failed",
+}
+
+// ErrorMessage returns the message telling the user what went wrong,
+// the errors of the server are prefixed with a hint on their cause
+func ErrorMessage(err error) string {
+	var typed *models.Error
+	if !errors.As(err, &typed) {
+		return err.Error()
+	}
+	if hint, ok := errorHints[typed.Type]; ok {
+		return fmt.Sprintf("%s (%s)", hint, err)
+	}
+	return err.Error()
+}
+
+// Fail prints the message of err and exits with a non-zero
+// status, it does nothing when there is no error
+func Fail(err error) {
+	if err == nil {
+		return
+	}
+	printError(os.Stderr, err)
+	os.Exit(1)
+}
+
+func printError(out io.Writer, err error) {
+	fmt.Fprintf(out, "Error: %s\n", ErrorMessage(err))
+}
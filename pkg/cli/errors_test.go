@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestErrorMessage(t *testing.T) {
+	assert.Equal(t, ErrorMessage(errors.New("template \"daily\" does not exist")), "template \"daily\" does not exist")
+	assert.Equal(t, ErrorMessage(models.NewError(models.ErrorTypeNotFound, "task 3 does not exist")), "task 3 does not exist")
+	assert.Equal(t,
+		ErrorMessage(models.NewError(models.ErrorTypeUnavailable, "dial unix /tmp/pomo.sock: connect: connection refused")),
+		"cannot reach the pomo server, is it running? (dial unix /tmp/pomo.sock: connect: connection refused)")
+
+	out := &bytes.Buffer{}
+	printError(out, models.NewError(models.ErrorTypeInternal, "disk full"))
+	assert.Equal(t, out.String(), "Error: the pomo server failed (disk full)\n")
+}
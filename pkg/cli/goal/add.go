@@ -0,0 +1,73 @@
+package goal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type addOptions struct {
+	weekly    bool
+	pomodoros int
+	hours     float64
+	tag       string
+}
+
+// NewGoalAddCommand returns a cobra command for `goal add`
+func NewGoalAddCommand(pomoCli cli.Cli) *cobra.Command {
+	options := addOptions{}
+
+	goalAddCmd := &cobra.Command{
+		Use:   "add",
+		Short: "add a goal",
+		Long:  `add a daily or weekly goal of pomodoros or focused hours`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(add(pomoCli, &options), pomoCli.Logger())
+		},
+	}
+
+	flags := goalAddCmd.Flags()
+	flags.BoolVarP(&options.weekly, "weekly", "w", false, "reach the goal every week instead of every day")
+	flags.IntVarP(&options.pomodoros, "pomodoros", "p", 0, "number of pomodoros to reach")
+	flags.Float64VarP(&options.hours, "hours", "H", 0, "focused hours to reach")
+	flags.StringVarP(&options.tag, "tag", "t", "", "only count the pomodoros of tasks with this tag")
+
+	return goalAddCmd
+}
+
+// newGoal returns the goal described by the options
+func newGoal(options *addOptions) (*models.Goal, error) {
+	goal := &models.Goal{Period: models.GoalDaily, Tag: options.tag}
+	if options.weekly {
+		goal.Period = models.GoalWeekly
+	}
+	switch {
+	case options.pomodoros > 0 && options.hours > 0:
+		return nil, errors.New("set either --pomodoros or --hours")
+	case options.pomodoros > 0:
+		goal.Unit = models.GoalPomodoros
+		goal.Target = float64(options.pomodoros)
+	case options.hours > 0:
+		goal.Unit = models.GoalHours
+		goal.Target = options.hours
+	default:
+		return nil, errors.New("set the target with --pomodoros or --hours")
+	}
+	return goal, goal.Validate()
+}
+
+func add(pomoCli cli.Cli, options *addOptions) error {
+	goal, err := newGoal(options)
+	if err != nil {
+		return err
+	}
+	goalID, err := pomoCli.Client().CreateGoal(goal)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Goal %d added\n", goalID)
+	return nil
+}
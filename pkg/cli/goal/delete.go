@@ -0,0 +1,26 @@
+package goal
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewGoalDeleteCommand returns a cobra command for `goal delete`
+func NewGoalDeleteCommand(pomoCli cli.Cli) *cobra.Command {
+	var goalID int
+
+	goalDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "delete a goal",
+		Long:  `delete a goal, its history is kept in the pomodoros`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().DeleteGoalByID(goalID), pomoCli.Logger())
+		},
+	}
+
+	flags := goalDeleteCmd.Flags()
+	flags.IntVarP(&goalID, "goalID", "g", -1, "ID of the goal to delete")
+	goalDeleteCmd.MarkFlagRequired("goalID")
+
+	return goalDeleteCmd
+}
@@ -0,0 +1,87 @@
+package goal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// goal command
+//
+//	pomo
+//	 ├── goal
+//	 │   ├── add
+//	 │   ├── delete
+//	 │   └── history
+//
+// /
+// NewGoalCommand returns a cobra command for `goal` subcommands
+func NewGoalCommand(pomoCli cli.Cli) *cobra.Command {
+	goalCmd := &cobra.Command{
+		Use:   "goal",
+		Short: "daily and weekly goals",
+		Long:  "show the progress of the goals, add or delete them",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(progress(pomoCli), pomoCli.Logger())
+		},
+	}
+	goalCmd.AddCommand(
+		NewGoalAddCommand(pomoCli),
+		NewGoalDeleteCommand(pomoCli),
+		NewGoalHistoryCommand(pomoCli),
+	)
+	return goalCmd
+}
+
+// progress prints the progress of every goal in the current period
+func progress(pomoCli cli.Cli) error {
+	goals, err := pomoCli.Client().GetGoals()
+	if err != nil {
+		return err
+	}
+	if len(goals) == 0 {
+		fmt.Println("No goals, add one with `goal add`")
+		return nil
+	}
+	tasks, err := pomoCli.Client().GetTaskList()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, goal := range goals {
+		fmt.Printf("%d: ", goal.ID)
+		printProgress(goal.Progress(*tasks, now))
+		fmt.Println()
+	}
+	return nil
+}
+
+// printProgress prints the progress green once the goal is hit
+func printProgress(progress models.GoalProgress) {
+	if progress.Hit() {
+		color.New(color.FgGreen).Print(progress)
+	} else {
+		fmt.Print(progress)
+	}
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -0,0 +1,66 @@
+package goal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewGoalHistoryCommand returns a cobra command for `goal history`
+func NewGoalHistoryCommand(pomoCli cli.Cli) *cobra.Command {
+	var periods int
+
+	goalHistoryCmd := &cobra.Command{
+		Use:   "history",
+		Short: "show the hit and missed periods",
+		Long:  `show whether each goal was hit or missed in the last days or weeks since it was created, the current one is in progress until it is hit or ends`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(history(pomoCli, periods), pomoCli.Logger())
+		},
+	}
+
+	flags := goalHistoryCmd.Flags()
+	flags.IntVarP(&periods, "periods", "n", 7, "number of days or weeks to show")
+
+	return goalHistoryCmd
+}
+
+func history(pomoCli cli.Cli, periods int) error {
+	if periods < 1 {
+		periods = 1
+	}
+	goals, err := pomoCli.Client().GetGoals()
+	if err != nil {
+		return err
+	}
+	tasks, err := pomoCli.Client().GetTaskList()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, goal := range goals {
+		hits, settled := 0, 0
+		history := goal.History(*tasks, now, periods)
+		fmt.Printf("%d: %s\n", goal.ID, history[len(history)-1])
+		for _, progress := range history {
+			fmt.Printf("  %s ", progress.Start.Format("2006-01-02"))
+			switch {
+			case progress.Hit():
+				hits++
+				settled++
+				color.New(color.FgGreen).Print("hit        ")
+			case progress.Missed():
+				settled++
+				color.New(color.FgRed).Print("missed     ")
+			default:
+				color.New(color.FgYellow).Print("in progress")
+			}
+			fmt.Printf(" %s\n", progress)
+		}
+		fmt.Printf("  %d/%d hit\n", hits, settled)
+	}
+	return nil
+}
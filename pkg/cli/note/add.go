@@ -0,0 +1,40 @@
+package note
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type addOptions struct {
+	taskID int
+	note   models.Note
+	last   bool
+}
+
+// NewNoteAddCommand returns a cobra command for `note add`
+func NewNoteAddCommand(pomoCli cli.Cli) *cobra.Command {
+	options := addOptions{}
+
+	noteAddCmd := &cobra.Command{
+		Use:   "add",
+		Short: "add a note to a task",
+		Long:  `add a note to a task, or to one of its pomodoros with --pomodoro or --last`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if options.last {
+				options.note.Pomodoro = models.NoteLastPomodoro
+			}
+			maybe(pomoCli.Client().AddNote(options.taskID, options.note), pomoCli.Logger())
+		},
+	}
+
+	flags := noteAddCmd.Flags()
+	flags.IntVarP(&options.taskID, "taskID", "t", 0, "ID of the task the note is about")
+	flags.StringVarP(&options.note.Text, "message", "m", "", "text of the note")
+	flags.IntVarP(&options.note.Pomodoro, "pomodoro", "p", 0, "number of the pomodoro the note is about, counted from 1")
+	flags.BoolVarP(&options.last, "last", "l", false, "the note is about the last pomodoro of the task")
+	noteAddCmd.MarkFlagRequired("taskID")
+	noteAddCmd.MarkFlagRequired("message")
+
+	return noteAddCmd
+}
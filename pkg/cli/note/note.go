@@ -0,0 +1,46 @@
+package note
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// note command
+//
+//	pomo
+//	 ├── note
+//	 │   └── add
+//
+// /
+// NewNoteCommand returns a cobra command for `note` subcommands
+func NewNoteCommand(pomoCli cli.Cli) *cobra.Command {
+	noteCmd := &cobra.Command{
+		Use:   "note",
+		Short: "operations regarding the notes",
+		Long:  "record notes about tasks and their pomodoros",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	noteCmd.AddCommand(
+		NewNoteAddCommand(pomoCli),
+	)
+	return noteCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
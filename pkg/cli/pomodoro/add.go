@@ -0,0 +1,56 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type addOptions struct {
+	taskID int
+	start  string
+	end    string
+	note   string
+}
+
+// NewPomodoroAddCommand returns a cobra command for `pomodoro add`
+func NewPomodoroAddCommand(pomoCli cli.Cli) *cobra.Command {
+	options := addOptions{}
+
+	pomodoroAddCmd := &cobra.Command{
+		Use:   "add",
+		Short: "add a pomodoro to a task",
+		Long:  `add a pomodoro that was not recorded to a task, times are "2006-01-02 15:04" or "15:04" for today`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(add(pomoCli, options, time.Now()), pomoCli.Logger())
+		},
+	}
+
+	flags := pomodoroAddCmd.Flags()
+	flags.IntVarP(&options.taskID, "task", "t", 0, "ID of the task the pomodoro belongs to")
+	flags.StringVarP(&options.start, "start", "s", "", "when the pomodoro started")
+	flags.StringVarP(&options.end, "end", "e", "", "when the pomodoro ended")
+	flags.StringVarP(&options.note, "note", "n", "", "what was accomplished in the pomodoro")
+	pomodoroAddCmd.MarkFlagRequired("task")
+	pomodoroAddCmd.MarkFlagRequired("start")
+	pomodoroAddCmd.MarkFlagRequired("end")
+
+	return pomodoroAddCmd
+}
+
+func add(pomoCli cli.Cli, options addOptions, now time.Time) error {
+	pomodoro := models.Pomodoro{Note: options.note}
+	var err error
+	if pomodoro.Start, err = parseTime(options.start, now); err != nil {
+		return err
+	}
+	if pomodoro.End, err = parseTime(options.end, now); err != nil {
+		return err
+	}
+	if err := pomodoro.Validate(); err != nil {
+		return err
+	}
+	return pomoCli.Client().CreatePomodoro(options.taskID, pomodoro)
+}
@@ -0,0 +1,26 @@
+package pomodoro
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewPomodoroDeleteCommand returns a cobra command for `pomodoro delete`
+func NewPomodoroDeleteCommand(pomoCli cli.Cli) *cobra.Command {
+	var pomodoroID int
+
+	pomodoroDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "delete a pomodoro",
+		Long:  `delete a single pomodoro, the task and its other pomodoros are kept`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().DeletePomodoroByID(pomodoroID), pomoCli.Logger())
+		},
+	}
+
+	flags := pomodoroDeleteCmd.Flags()
+	flags.IntVarP(&pomodoroID, "pomodoroID", "i", -1, "ID of the pomodoro to delete")
+	pomodoroDeleteCmd.MarkFlagRequired("pomodoroID")
+
+	return pomodoroDeleteCmd
+}
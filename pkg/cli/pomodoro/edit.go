@@ -0,0 +1,62 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+type editOptions struct {
+	pomodoroID int
+	start      string
+	end        string
+	note       string
+}
+
+// NewPomodoroEditCommand returns a cobra command for `pomodoro edit`
+func NewPomodoroEditCommand(pomoCli cli.Cli) *cobra.Command {
+	options := editOptions{}
+
+	pomodoroEditCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "correct a pomodoro",
+		Long:  `correct the start, the end or the note of a pomodoro, the flags not given are kept`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(edit(pomoCli, cmd.Flags().Changed, options, time.Now()), pomoCli.Logger())
+		},
+	}
+
+	flags := pomodoroEditCmd.Flags()
+	flags.IntVarP(&options.pomodoroID, "pomodoroID", "i", -1, "ID of the pomodoro to correct")
+	flags.StringVarP(&options.start, "start", "s", "", "when the pomodoro started")
+	flags.StringVarP(&options.end, "end", "e", "", "when the pomodoro ended")
+	flags.StringVarP(&options.note, "note", "n", "", "what was accomplished in the pomodoro, replacing its note")
+	pomodoroEditCmd.MarkFlagRequired("pomodoroID")
+
+	return pomodoroEditCmd
+}
+
+func edit(pomoCli cli.Cli, changed func(name string) bool, options editOptions, now time.Time) error {
+	pomodoro, err := pomoCli.Client().GetPomodoro(options.pomodoroID)
+	if err != nil {
+		return err
+	}
+	if changed("start") {
+		if pomodoro.Start, err = parseTime(options.start, now); err != nil {
+			return err
+		}
+	}
+	if changed("end") {
+		if pomodoro.End, err = parseTime(options.end, now); err != nil {
+			return err
+		}
+	}
+	if changed("note") {
+		pomodoro.Note = options.note
+	}
+	if err := pomodoro.Validate(); err != nil {
+		return err
+	}
+	return pomoCli.Client().UpdatePomodoro(*pomodoro)
+}
@@ -0,0 +1,80 @@
+package pomodoro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	taskID int
+	from   string
+	to     string
+	asJSON bool
+}
+
+// NewPomodoroListCommand returns a cobra command for `pomodoro list`
+func NewPomodoroListCommand(pomoCli cli.Cli) *cobra.Command {
+	options := listOptions{}
+
+	pomodoroListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the pomodoros",
+		Long:  `list the pomodoros of a task or of a time range with their IDs, to edit or delete them`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(list(pomoCli, options, time.Now()), pomoCli.Logger())
+		},
+	}
+
+	flags := pomodoroListCmd.Flags()
+	flags.IntVarP(&options.taskID, "task", "t", 0, "ID of the task the pomodoros belong to")
+	flags.StringVarP(&options.from, "from", "f", "", "list the pomodoros started from this time")
+	flags.StringVar(&options.to, "to", "", "list the pomodoros started before this time")
+	flags.BoolVarP(&options.asJSON, "json", "j", false, "output the pomodoros as JSON")
+
+	return pomodoroListCmd
+}
+
+func list(pomoCli cli.Cli, options listOptions, now time.Time) error {
+	query := models.PomodoroQuery{TaskID: options.taskID}
+	var err error
+	if options.from != "" {
+		if query.From, err = parseTime(options.from, now); err != nil {
+			return err
+		}
+	}
+	if options.to != "" {
+		if query.To, err = parseTime(options.to, now); err != nil {
+			return err
+		}
+	}
+	pomodoros, err := pomoCli.Client().GetPomodoros(query)
+	if err != nil {
+		return err
+	}
+	if options.asJSON {
+		return json.NewEncoder(os.Stdout).Encode(pomodoros)
+	}
+	printPomodoros(os.Stdout, pomoCli.Config().Server.DatetimeFormat, pomodoros)
+	return nil
+}
+
+// printPomodoros prints a pomodoro per line, eg:
+// 3: task 2 2021-01-16 09:00 - 2021-01-16 09:25 (25m0s) drafted the outline
+func printPomodoros(w io.Writer, datetimeFormat string, pomodoros []*models.Pomodoro) {
+	for _, pomodoro := range pomodoros {
+		fmt.Fprintf(w, "%d: task %d %s - %s (%s)", pomodoro.ID, pomodoro.TaskID, pomodoro.Start.Format(datetimeFormat),
+			pomodoro.End.Format(datetimeFormat), pomodoro.Duration().Round(time.Second))
+		if pomodoro.Note != "" {
+			fmt.Fprintf(w, " %s", strings.ReplaceAll(pomodoro.Note, "\n", " / "))
+		}
+		fmt.Fprintln(w)
+	}
+}
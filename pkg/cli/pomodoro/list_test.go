@@ -0,0 +1,22 @@
+package pomodoro
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintPomodoros(t *testing.T) {
+	start := time.Date(2021, 1, 16, 9, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	printPomodoros(buf, "2006-01-02 15:04", []*models.Pomodoro{
+		{ID: 3, TaskID: 2, Start: start, End: start.Add(25 * time.Minute), Note: "drafted the outline\ngot distracted"},
+		{ID: 5, TaskID: 2, Start: start.Add(time.Hour), End: start.Add(time.Hour + 20*time.Minute)},
+	})
+	assert.Equal(t, buf.String(),
+		"3: task 2 2021-01-16 09:00 - 2021-01-16 09:25 (25m0s) drafted the outline / got distracted\n"+
+			"5: task 2 2021-01-16 10:00 - 2021-01-16 10:20 (20m0s)\n")
+}
@@ -0,0 +1,75 @@
+package pomodoro
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// pomodoro command
+//
+//	pomo
+//	 ├── pomodoro
+//	 │   ├── add
+//	 │   ├── delete
+//	 │   ├── edit
+//	 │   └── list
+//
+// /
+// NewPomodoroCommand returns a cobra command for `pomodoro` subcommands
+func NewPomodoroCommand(pomoCli cli.Cli) *cobra.Command {
+	pomodoroCmd := &cobra.Command{
+		Use:   "pomodoro",
+		Short: "operations regarding the pomodoros",
+		Long:  "enter pomodoros lost to a crash or a sleeping laptop, correct or delete them",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	pomodoroCmd.AddCommand(
+		NewPomodoroAddCommand(pomoCli),
+		NewPomodoroDeleteCommand(pomoCli),
+		NewPomodoroEditCommand(pomoCli),
+		NewPomodoroListCommand(pomoCli),
+	)
+	return pomodoroCmd
+}
+
+// timeLayouts are the accepted --start and --end formats,
+// the ones without a date are taken as today
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "15:04:05", "15:04"}
+
+// parseTime reads value in the local time zone
+func parseTime(value string, now time.Time) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		parsed, err := time.ParseInLocation(layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+		if parsed.Year() == 0 {
+			year, month, day := now.Date()
+			parsed = time.Date(year, month, day, parsed.Hour(), parsed.Minute(), parsed.Second(), 0, now.Location())
+		}
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("could not parse time %q, use \"2006-01-02 15:04\" or \"15:04\"", value)
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -0,0 +1,27 @@
+package pomodoro
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 30, 0, 0, time.Local)
+
+	parsed, err := parseTime("2026-02-27 09:15", now)
+	assert.NilError(t, err)
+	assert.Assert(t, parsed.Equal(time.Date(2026, 2, 27, 9, 15, 0, 0, time.Local)))
+
+	parsed, err = parseTime("09:15", now)
+	assert.NilError(t, err)
+	assert.Assert(t, parsed.Equal(time.Date(2026, 3, 2, 9, 15, 0, 0, time.Local)), "today")
+
+	parsed, err = parseTime("2026-02-27T09:15:00Z", now)
+	assert.NilError(t, err)
+	assert.Assert(t, parsed.Equal(time.Date(2026, 2, 27, 9, 15, 0, 0, time.UTC)))
+
+	_, err = parseTime("yesterday", now)
+	assert.ErrorContains(t, err, "could not parse")
+}
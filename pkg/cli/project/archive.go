@@ -0,0 +1,26 @@
+package project
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewProjectArchiveCommand returns a cobra command for `project archive`
+func NewProjectArchiveCommand(pomoCli cli.Cli) *cobra.Command {
+	var projectID int
+
+	projectArchiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "archive a project",
+		Long:  `archive a project and the projects nested under it, their tasks are kept`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().ArchiveProject(projectID), pomoCli.Logger())
+		},
+	}
+
+	flags := projectArchiveCmd.Flags()
+	flags.IntVarP(&projectID, "projectID", "p", -1, "ID of the project to archive")
+	projectArchiveCmd.MarkFlagRequired("projectID")
+
+	return projectArchiveCmd
+}
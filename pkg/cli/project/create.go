@@ -0,0 +1,40 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewProjectCreateCommand returns a cobra command for `project create`
+func NewProjectCreateCommand(pomoCli cli.Cli) *cobra.Command {
+	project := models.Project{}
+
+	projectCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a project",
+		Long:  `create a project, nested under --parent when given`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(create(pomoCli, &project), pomoCli.Logger())
+		},
+	}
+
+	flags := projectCreateCmd.Flags()
+	flags.StringVarP(&project.Name, "name", "n", "", "name of the project")
+	flags.StringVarP(&project.Color, "color", "c", "", "color of the project, a terminal color name or 0-255 or #rrggbb")
+	flags.IntVarP(&project.ParentID, "parent", "p", 0, "ID of the project to nest this one under")
+	projectCreateCmd.MarkFlagRequired("name")
+
+	return projectCreateCmd
+}
+
+func create(pomoCli cli.Cli, project *models.Project) error {
+	projectID, err := pomoCli.Client().CreateProject(project)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Project %d created\n", projectID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	runnerC "github.com/joaorufino/pomo/pkg/runner"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	asJSON bool
+	all    bool
+}
+
+// NewProjectListCommand returns a cobra command for `project list`
+func NewProjectListCommand(pomoCli cli.Cli) *cobra.Command {
+	options := listOptions{}
+
+	projectListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the projects",
+		Long:  `list the projects as a tree`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(list(pomoCli, &options), pomoCli.Logger())
+		},
+	}
+
+	flags := projectListCmd.Flags()
+	flags.BoolVarP(&options.asJSON, "json", "j", false, "output the projects as JSON")
+	flags.BoolVarP(&options.all, "all", "a", false, "include the archived projects")
+
+	return projectListCmd
+}
+
+func list(pomoCli cli.Cli, options *listOptions) error {
+	projects, err := pomoCli.Client().GetProjects()
+	if err != nil {
+		return err
+	}
+	if !options.all {
+		active := models.Projects{}
+		for _, project := range projects {
+			if !project.Archived {
+				active = append(active, project)
+			}
+		}
+		projects = active
+	}
+	if options.asJSON {
+		return json.NewEncoder(os.Stdout).Encode(&projects)
+	}
+	printTree(os.Stdout, projects, 0, 0)
+	return nil
+}
+
+// printTree prints the projects under parentID indented by depth
+func printTree(w io.Writer, projects models.Projects, parentID, depth int) {
+	for _, project := range projects.Children(parentID) {
+		name := project.Name
+		if c := runnerC.TermColor(project.Color); c != nil {
+			name = c.Sprint(name)
+		}
+		fmt.Fprintf(w, "%s%d: %s", strings.Repeat("  ", depth), project.ID, name)
+		if project.Archived {
+			fmt.Fprint(w, " (archived)")
+		}
+		fmt.Fprintln(w)
+		printTree(w, projects, project.ID, depth+1)
+	}
+}
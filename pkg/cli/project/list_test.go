@@ -0,0 +1,20 @@
+package project
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintTree(t *testing.T) {
+	projects := models.Projects{
+		{ID: 1, Name: "Work"},
+		{ID: 2, Name: "Website", ParentID: 1, Archived: true},
+		{ID: 3, Name: "Home"},
+	}
+	out := &bytes.Buffer{}
+	printTree(out, projects, 0, 0)
+	assert.Equal(t, out.String(), "1: Work\n  2: Website (archived)\n3: Home\n")
+}
@@ -0,0 +1,50 @@
+package project
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// project command
+//
+//	pomo
+//	 ├── project
+//	 │   ├── archive
+//	 │   ├── create
+//	 │   └── list
+//
+// /
+// NewProjectCommand returns a cobra command for `project` subcommands
+func NewProjectCommand(pomoCli cli.Cli) *cobra.Command {
+	projectCmd := &cobra.Command{
+		Use:   "project",
+		Short: "operations regarding the projects",
+		Long:  "group tasks in projects, which can be nested",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	projectCmd.AddCommand(
+		NewProjectArchiveCommand(pomoCli),
+		NewProjectCreateCommand(pomoCli),
+		NewProjectListCommand(pomoCli),
+	)
+	return projectCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -11,8 +11,17 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/cli/db"
+	"github.com/joaorufino/pomo/pkg/cli/goal"
+	"github.com/joaorufino/pomo/pkg/cli/note"
+	"github.com/joaorufino/pomo/pkg/cli/pomodoro"
+	"github.com/joaorufino/pomo/pkg/cli/project"
 	"github.com/joaorufino/pomo/pkg/cli/server"
+	"github.com/joaorufino/pomo/pkg/cli/sync"
+	"github.com/joaorufino/pomo/pkg/cli/tag"
 	"github.com/joaorufino/pomo/pkg/cli/task"
+	"github.com/joaorufino/pomo/pkg/cli/template"
+	"github.com/joaorufino/pomo/pkg/cli/trash"
 	"github.com/joaorufino/pomo/pkg/conf"
 )
 
@@ -27,6 +36,8 @@ func NewRootCommand(pomoCli *cli.PomoCli) *cobra.Command {
 		Use:               pomoCli.Executable(),
 		PersistentPreRunE: prerun,
 		PersistentPostRun: cleanup,
+		// printed by Execute with the hint on their cause
+		SilenceErrors: true,
 	}
 
 }
@@ -45,12 +56,20 @@ func Execute() {
 	}
 	rootCmd.AddCommand(
 		server.NewServerCommand(pomoCli),
-		task.NewTaskCommand(pomoCli))
+		task.NewTaskCommand(pomoCli),
+		task.NewAttachCommand(pomoCli),
+		goal.NewGoalCommand(pomoCli),
+		project.NewProjectCommand(pomoCli),
+		tag.NewTagCommand(pomoCli),
+		template.NewTemplateCommand(pomoCli),
+		note.NewNoteCommand(pomoCli),
+		pomodoro.NewPomodoroCommand(pomoCli),
+		trash.NewTrashCommand(pomoCli),
+		db.NewDBCommand(pomoCli),
+		sync.NewSyncCommand(pomoCli))
 
 	// Run the program
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-	}
+	cli.Fail(rootCmd.Execute())
 }
 
 func prerun(cmd *cobra.Command, args []string) error {
@@ -86,7 +105,7 @@ func cleanup(cmd *cobra.Command, args []string) {
 
 func maybe(err error, logger *zap.SugaredLogger) {
 	if err != nil {
-		logger.Fatalf("Error:\n%s\n", err)
-		os.Exit(1)
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
 	}
 }
@@ -13,7 +13,10 @@ import (
 //   │   ├── config
 //   │   ├── init
 //   |   ├── status
-//   │   └── version
+//   │   ├── version
+//   │   └── webhooks
+//   │       ├── list
+//   │       └── test
 ///
 
 // NewServerCommand returns a cobra command for `server` subcommands
@@ -32,12 +35,14 @@ func NewServerCommand(pomoCli cli.Cli) *cobra.Command {
 		NewServerStatusCommand(pomoCli),
 		NewServerInitCommand(pomoCli),
 		NewServerVersionCommand(pomoCli),
+		NewServerWebhooksCommand(pomoCli),
 	)
 	return serverCmd
 }
 
 func maybe(err error, logger *zap.SugaredLogger) {
 	if err != nil {
-		logger.Fatalf("Error:%s", err)
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
 	}
 }
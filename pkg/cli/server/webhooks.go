@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/webhook"
+	"github.com/joaorufino/pomo/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+// NewServerWebhooksCommand returns a cobra command for `webhooks` subcommands
+func NewServerWebhooksCommand(pomoCli cli.Cli) *cobra.Command {
+	webhooksCmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Manage outgoing webhooks",
+		Long:  `List and test the configured outgoing webhooks`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	webhooksCmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List webhook endpoints and queued deliveries",
+			Long:  `List the configured webhook endpoints and the deliveries waiting in the queue`,
+			Run: func(cmd *cobra.Command, args []string) {
+				maybe(listWebhooks(pomoCli), pomoCli.Logger())
+			},
+		},
+		&cobra.Command{
+			Use:   "test [NAME...]",
+			Short: "Send a ping event to webhook endpoints",
+			Long:  `Send a ping event to the named endpoints, or to all of them when no name is given`,
+			Run: func(cmd *cobra.Command, args []string) {
+				maybe(testWebhooks(pomoCli, args), pomoCli.Logger())
+			},
+		},
+	)
+	return webhooksCmd
+}
+
+func listWebhooks(pomoCli cli.Cli) error {
+	db, err := store.NewStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	dispatcher := webhook.New(db, pomoCli.Config().Webhooks)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tURL\tEVENTS")
+	for _, endpoint := range dispatcher.Endpoints() {
+		events := "*"
+		if len(endpoint.Events) > 0 {
+			events = strings.Join(endpoint.Events, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", endpoint.Name, endpoint.URL, events)
+	}
+	w.Flush()
+
+	deliveries, err := db.WebhookDeliveryList(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	fmt.Println()
+	fmt.Fprintln(w, "ID\tENDPOINT\tEVENT\tATTEMPTS\tSTATE\tLAST ERROR")
+	for _, delivery := range deliveries {
+		state := "retry at " + delivery.NextAttempt.Format(pomoCli.Config().Server.DatetimeFormat)
+		if delivery.Failed {
+			state = "failed at " + delivery.NextAttempt.Format(pomoCli.Config().Server.DatetimeFormat)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n",
+			delivery.ID,
+			delivery.Endpoint,
+			delivery.Event.Type,
+			delivery.Attempts,
+			state,
+			delivery.LastError,
+		)
+	}
+	return w.Flush()
+}
+
+func testWebhooks(pomoCli cli.Cli, names []string) error {
+	dispatcher := webhook.New(nil, pomoCli.Config().Webhooks)
+	event, err := webhook.NewEvent(models.EventPing, nil)
+	if err != nil {
+		return err
+	}
+	sent := 0
+	failed := 0
+	for _, endpoint := range dispatcher.Endpoints() {
+		if len(names) > 0 && !contains(names, endpoint.Name) {
+			continue
+		}
+		sent++
+		if err := dispatcher.Deliver(context.Background(), endpoint, event); err != nil {
+			failed++
+			fmt.Printf("%s: %s\n", endpoint.Name, err)
+		} else {
+			fmt.Printf("%s: ok\n", endpoint.Name)
+		}
+	}
+	if sent == 0 {
+		return fmt.Errorf("no matching webhook endpoints configured")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhook endpoints failed", failed, sent)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
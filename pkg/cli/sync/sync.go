@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// NewSyncCommand returns a cobra command for `sync`
+func NewSyncCommand(pomoCli cli.Cli) *cobra.Command {
+	var (
+		list   bool
+		remote string
+	)
+
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "send the queued writes or sync with another server",
+		Long:  "the rest client queues the tasks, pomodoros and status updates it cannot send in client.journal, they are sent with the next write or by this command. With --remote the server then exchanges its tasks, pomodoros and notes with the pomo rest server at the URL, the newest change of each one is kept on both and the notes missing on either are added",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if list {
+				queue, err := pomoCli.Client().GetQueue()
+				maybe(err, pomoCli.Logger())
+				printQueue(os.Stdout, pomoCli.Config().Server.DatetimeFormat, queue)
+				return
+			}
+			sent, err := pomoCli.Client().FlushQueue()
+			fmt.Printf("Sent %d queued requests\n", sent)
+			maybe(err, pomoCli.Logger())
+			if remote != "" {
+				report, err := pomoCli.Client().Sync(remote)
+				maybe(err, pomoCli.Logger())
+				printReport(os.Stdout, *report)
+			}
+		},
+	}
+
+	flags := syncCmd.Flags()
+	flags.BoolVarP(&list, "list", "l", false, "list the queued requests instead of sending them")
+	flags.StringVarP(&remote, "remote", "r", "", "URL of the pomo rest server to sync with")
+
+	return syncCmd
+}
+
+// printQueue prints a queued request per line, eg:
+// 2021-01-16 19:30 pomodoro of task 3: 2021-01-16 19:05 (25m0s)
+func printQueue(w io.Writer, datetimeFormat string, queue []models.QueuedRequest) {
+	for _, request := range queue {
+		fmt.Fprintf(w, "%s %s", request.Time.Format(datetimeFormat), request.Kind)
+		switch {
+		case request.Task != nil:
+			fmt.Fprintf(w, " %d: %s", request.TaskID, request.Task.Message)
+		case request.Pomodoro != nil:
+			fmt.Fprintf(w, " of task %d: %s (%s)", request.TaskID, request.Pomodoro.Start.Format(datetimeFormat), request.Pomodoro.Duration())
+		case request.Status != nil:
+			fmt.Fprintf(w, " of task %d: %s", request.Status.TaskID, request.Status.State)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// printReport prints the changes made on both servers, eg:
+// local: 2 created, 1 updated, 0 deleted
+func printReport(w io.Writer, report models.SyncReport) {
+	for _, side := range []struct {
+		name   string
+		result models.SyncResult
+	}{{"local", report.Local}, {"remote", report.Remote}} {
+		fmt.Fprintf(w, "%s: %d created, %d updated, %d deleted\n", side.name, side.result.Created, side.result.Updated, side.result.Deleted)
+	}
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
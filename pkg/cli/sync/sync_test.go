@@ -0,0 +1,33 @@
+package sync
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintQueue(t *testing.T) {
+	start := time.Date(2021, 1, 16, 19, 5, 0, 0, time.UTC)
+	queued := start.Add(25 * time.Minute)
+	buf := &bytes.Buffer{}
+	printQueue(buf, "2006-01-02 15:04", []models.QueuedRequest{
+		{Time: queued, Kind: models.QueuedTask, TaskID: -1, Task: &models.Task{Message: "write the report"}},
+		{Time: queued, Kind: models.QueuedPomodoro, TaskID: 3, Pomodoro: &models.Pomodoro{Start: start, End: queued}},
+		{Time: queued, Kind: models.QueuedStatus, Status: &models.Status{TaskID: 3, State: models.COMPLETE}},
+	})
+	assert.Equal(t, buf.String(), "2021-01-16 19:30 task -1: write the report\n"+
+		"2021-01-16 19:30 pomodoro of task 3: 2021-01-16 19:05 (25m0s)\n"+
+		"2021-01-16 19:30 status of task 3: COMPLETE\n")
+}
+
+func TestPrintReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	printReport(buf, models.SyncReport{
+		Local:  models.SyncResult{Created: 2, Updated: 1},
+		Remote: models.SyncResult{Deleted: 3},
+	})
+	assert.Equal(t, buf.String(), "local: 2 created, 1 updated, 0 deleted\nremote: 0 created, 0 updated, 3 deleted\n")
+}
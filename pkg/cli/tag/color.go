@@ -0,0 +1,28 @@
+package tag
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTagColorCommand returns a cobra command for `tag color`
+func NewTagColorCommand(pomoCli cli.Cli) *cobra.Command {
+	tag := models.Tag{}
+
+	tagColorCmd := &cobra.Command{
+		Use:   "color",
+		Short: "set the color of a tag",
+		Long:  `set the color of a tag, overriding the colors of the configuration, no --color removes it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().SetTagColor(tag), pomoCli.Logger())
+		},
+	}
+
+	flags := tagColorCmd.Flags()
+	flags.StringVarP(&tag.Name, "tag", "t", "", "tag to color")
+	flags.StringVarP(&tag.Color, "color", "c", "", "a terminal color name, 0-255 or #rrggbb")
+	tagColorCmd.MarkFlagRequired("tag")
+
+	return tagColorCmd
+}
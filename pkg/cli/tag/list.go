@@ -0,0 +1,55 @@
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	runnerC "github.com/joaorufino/pomo/pkg/runner"
+	"github.com/spf13/cobra"
+)
+
+// NewTagListCommand returns a cobra command for `tag list`
+func NewTagListCommand(pomoCli cli.Cli) *cobra.Command {
+	var asJSON bool
+
+	tagListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the tags",
+		Long:  `list the tags with their color and number of tasks`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(list(pomoCli, asJSON), pomoCli.Logger())
+		},
+	}
+
+	flags := tagListCmd.Flags()
+	flags.BoolVarP(&asJSON, "json", "j", false, "output the tags as JSON")
+
+	return tagListCmd
+}
+
+func list(pomoCli cli.Cli, asJSON bool) error {
+	tags, err := pomoCli.Client().GetTags()
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return json.NewEncoder(os.Stdout).Encode(&tags)
+	}
+	printTags(os.Stdout, tags, runnerC.NewTagColors(pomoCli.Config().Colors, tags))
+	return nil
+}
+
+// printTags prints a tag per line in its color
+func printTags(w io.Writer, tags models.Tags, colors runnerC.TagColors) {
+	for _, tag := range tags {
+		name := tag.Name
+		if c := colors.Get(tag.Name); c != nil {
+			name = c.Sprint(name)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", name, tag.Count)
+	}
+}
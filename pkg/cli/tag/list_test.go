@@ -0,0 +1,17 @@
+package tag
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	runnerC "github.com/joaorufino/pomo/pkg/runner"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintTags(t *testing.T) {
+	tags := models.Tags{{Name: "docs", Color: "blue", Count: 3}, {Name: "work", Count: 1}}
+	out := &bytes.Buffer{}
+	printTags(out, tags, runnerC.NewTagColors(nil, tags))
+	assert.Equal(t, out.String(), "docs (3)\nwork (1)\n")
+}
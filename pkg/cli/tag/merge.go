@@ -0,0 +1,29 @@
+package tag
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTagMergeCommand returns a cobra command for `tag merge`
+func NewTagMergeCommand(pomoCli cli.Cli) *cobra.Command {
+	merge := models.TagRename{}
+
+	tagMergeCmd := &cobra.Command{
+		Use:   "merge",
+		Short: "merge tags into one",
+		Long:  `replace the --tag tags of every task by the --into tag, which keeps its color`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().RenameTags(merge), pomoCli.Logger())
+		},
+	}
+
+	flags := tagMergeCmd.Flags()
+	flags.StringSliceVarP(&merge.From, "tag", "t", []string{}, "tags to merge, repeat or separate with commas")
+	flags.StringVarP(&merge.To, "into", "i", "", "tag the others are merged into")
+	tagMergeCmd.MarkFlagRequired("tag")
+	tagMergeCmd.MarkFlagRequired("into")
+
+	return tagMergeCmd
+}
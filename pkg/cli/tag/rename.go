@@ -0,0 +1,45 @@
+package tag
+
+import (
+	"fmt"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTagRenameCommand returns a cobra command for `tag rename`
+func NewTagRenameCommand(pomoCli cli.Cli) *cobra.Command {
+	var from, to string
+
+	tagRenameCmd := &cobra.Command{
+		Use:   "rename",
+		Short: "rename a tag",
+		Long:  `rename a tag in every task, use merge when the new name is already a tag`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(rename(pomoCli, from, to), pomoCli.Logger())
+		},
+	}
+
+	flags := tagRenameCmd.Flags()
+	flags.StringVarP(&from, "tag", "t", "", "tag to rename")
+	flags.StringVarP(&to, "name", "n", "", "new name of the tag")
+	tagRenameCmd.MarkFlagRequired("tag")
+	tagRenameCmd.MarkFlagRequired("name")
+
+	return tagRenameCmd
+}
+
+func rename(pomoCli cli.Cli, from, to string) error {
+	tags, err := pomoCli.Client().GetTags()
+	if err != nil {
+		return err
+	}
+	if tags.ByName(from) == nil {
+		return fmt.Errorf("tag %q does not exist", from)
+	}
+	if tags.ByName(to) != nil {
+		return fmt.Errorf("tag %q already exists, merge the tags instead", to)
+	}
+	return pomoCli.Client().RenameTags(models.TagRename{From: []string{from}, To: to})
+}
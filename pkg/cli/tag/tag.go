@@ -0,0 +1,52 @@
+package tag
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// tag command
+//
+//	pomo
+//	 ├── tag
+//	 │   ├── color
+//	 │   ├── list
+//	 │   ├── merge
+//	 │   └── rename
+//
+// /
+// NewTagCommand returns a cobra command for `tag` subcommands
+func NewTagCommand(pomoCli cli.Cli) *cobra.Command {
+	tagCmd := &cobra.Command{
+		Use:   "tag",
+		Short: "operations regarding the tags",
+		Long:  "list the tags of the tasks, color, rename or merge them",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	tagCmd.AddCommand(
+		NewTagColorCommand(pomoCli),
+		NewTagListCommand(pomoCli),
+		NewTagMergeCommand(pomoCli),
+		NewTagRenameCommand(pomoCli),
+	)
+	return tagCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -0,0 +1,37 @@
+package task
+
+import (
+	"errors"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/joaorufino/pomo/pkg/runner"
+	"github.com/spf13/cobra"
+)
+
+// NewAttachCommand returns a cobra command for `attach`
+func NewAttachCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach",
+		Short: "attach to the running session",
+		Long:  `show the UI of a session running in the background, quitting leaves it running`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(attach(pomoCli), pomoCli.Logger())
+		},
+	}
+}
+
+func attach(pomoCli cli.Cli) error {
+	if pomoCli.Client() == nil {
+		return errors.New("client not defined")
+	}
+	return runner.Attach(pomoCli.Client())
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTaskSkipCommand returns a cobra command for `task skip`
+func NewTaskSkipCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "skip",
+		Short: "skip the current pomodoro or break",
+		Long:  `end the current pomodoro or break of the running session early`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(sendControl(pomoCli, models.Control{Action: models.ControlSkip}), pomoCli.Logger())
+		},
+	}
+}
+
+// NewTaskExtendCommand returns a cobra command for `task extend`
+func NewTaskExtendCommand(pomoCli cli.Cli) *cobra.Command {
+	var duration time.Duration
+
+	taskExtendCmd := &cobra.Command{
+		Use:   "extend",
+		Short: "extend the current pomodoro",
+		Long:  `add time to the current pomodoro of the running session`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(sendControl(pomoCli, models.Control{Action: models.ControlExtend, Duration: duration}), pomoCli.Logger())
+		},
+	}
+
+	flags := taskExtendCmd.Flags()
+	flags.DurationVarP(&duration, "duration", "d", 5*time.Minute, "time to add to the pomodoro")
+
+	return taskExtendCmd
+}
+
+// NewTaskRestartCommand returns a cobra command for `task restart`
+func NewTaskRestartCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart",
+		Short: "restart the current pomodoro",
+		Long:  `start the current pomodoro of the running session over`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(sendControl(pomoCli, models.Control{Action: models.ControlRestart}), pomoCli.Logger())
+		},
+	}
+}
+
+// NewTaskStopCommand returns a cobra command for `task stop`
+func NewTaskStopCommand(pomoCli cli.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "stop the running session",
+		Long:  `end the running session, the pomodoro in progress is saved`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(sendControl(pomoCli, models.Control{Action: models.ControlStop}), pomoCli.Logger())
+		},
+	}
+}
+
+func sendControl(pomoCli cli.Cli, control models.Control) error {
+	if pomoCli.Client() == nil {
+		return errors.New("client not defined")
+	}
+	if err := control.Validate(); err != nil {
+		return err
+	}
+	return pomoCli.Client().SendControl(control)
+}
@@ -1,6 +1,9 @@
 package task
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/joaorufino/pomo/pkg/cli"
@@ -14,18 +17,39 @@ type createOptions struct {
 	pomodoros int
 	start     bool
 	tags      []string
+	projectID int
+	template  string
+	run       models.RunOptions
+	// timed breaks and auto-start, only set when the flags are given
+	breakDuration     string
+	autoStartBreak    *bool
+	autoStartPomodoro *bool
 }
 
 // NewConfigCommand returns a cobra command for `config` subcommands
 func NewTaskCreateCommand(pomoCli cli.Cli) *cobra.Command {
 
 	options := createOptions{}
+	var autoStartBreak, autoStartPomodoro bool
 
 	taskCreateCmd := &cobra.Command{
 		Use:   "create",
 		Short: "create task",
 		Long:  `create task`,
 		Run: func(cmd *cobra.Command, args []string) {
+			flags := cmd.Flags()
+			if flags.Changed("auto-break") {
+				options.autoStartBreak = &autoStartBreak
+			}
+			if flags.Changed("auto-pomodoro") {
+				options.autoStartPomodoro = &autoStartPomodoro
+			}
+			if options.template != "" {
+				maybe(fromTemplate(pomoCli, flags.Changed, &options), pomoCli.Logger())
+			}
+			if options.message == "" {
+				maybe(errors.New("set the task --message or --from-template"), pomoCli.Logger())
+			}
 			create(pomoCli, &options)
 		},
 	}
@@ -37,31 +61,84 @@ func NewTaskCreateCommand(pomoCli cli.Cli) *cobra.Command {
 	flags.IntVarP(&options.pomodoros, "pomodoros", "p", 4, "number of pomodoros")
 	flags.StringSliceVarP(&options.tags, "tag", "t", []string{}, "tags associated with this task")
 	flags.BoolVarP(&options.start, "start", "s", false, "start pomodoro after creation")
+	flags.IntVarP(&options.projectID, "project", "P", 0, "ID of the project the task belongs to")
+	flags.StringVarP(&options.template, "from-template", "T", "", "name of the template the task is created from, the other flags override it")
+	addRunFlags(taskCreateCmd, &options.run)
+	flags.StringVar(&options.breakDuration, "break-duration", "", "duration of the timed breaks, defaults to the configured one")
+	flags.BoolVar(&autoStartBreak, "auto-break", false, "time the breaks from the end of each pomodoro, defaults to the configuration")
+	flags.BoolVar(&autoStartPomodoro, "auto-pomodoro", false, "start the next pomodoro once the break elapses, which times the breaks, defaults to the configuration")
 
-	//mandatory flags
+	//mandatory flags, unless the task is created from a template
 	flags.StringVarP(&options.message, "message", "m", "", "descriptive name of the given task")
-	taskCreateCmd.MarkFlagRequired("message")
 
 	return taskCreateCmd
 }
 
+// fromTemplate fills the options whose
+// flags are not changed from the named template
+func fromTemplate(pomoCli cli.Cli, changed func(name string) bool, options *createOptions) error {
+	templates, err := pomoCli.Client().GetTemplates()
+	if err != nil {
+		return err
+	}
+	template := templates.ByName(options.template)
+	if template == nil {
+		return fmt.Errorf("template %q does not exist", options.template)
+	}
+	if !changed("message") {
+		options.message = template.Message
+	}
+	if !changed("pomodoros") {
+		options.pomodoros = template.NPomodoros
+	}
+	if !changed("duration") {
+		options.duration = template.Duration.String()
+	}
+	if !changed("tag") {
+		options.tags = template.Tags
+	}
+	if !changed("project") {
+		options.projectID = template.ProjectID
+	}
+	return nil
+}
+
 // creates a task
 func create(pomoCli cli.Cli, options *createOptions) {
 	parsed, err := time.ParseDuration(options.duration)
 	maybe(err, pomoCli.Logger())
+	maybe(options.run.Validate(), pomoCli.Logger())
 
 	task := &models.Task{
-		Message:    options.message,
-		Tags:       options.tags,
-		NPomodoros: options.pomodoros,
-		Duration:   parsed,
+		Message:           options.message,
+		Tags:              options.tags,
+		NPomodoros:        options.pomodoros,
+		Duration:          parsed,
+		AutoStartBreak:    options.autoStartBreak,
+		AutoStartPomodoro: options.autoStartPomodoro,
+		ProjectID:         options.projectID,
+	}
+	if options.breakDuration != "" {
+		task.BreakDuration, err = time.ParseDuration(options.breakDuration)
+		maybe(err, pomoCli.Logger())
+	}
+	// the suggestion is a hint, a failure never stops the task creation
+	if tasks, err := pomoCli.Client().GetTaskList(); err == nil {
+		suggest(os.Stdout, task, *tasks)
 	}
 	taskID, err := pomoCli.Client().CreateTask(task)
 	maybe(err, pomoCli.Logger())
+	// the server was unreachable, the task is created by `pomo sync`
+	if taskID < 0 {
+		fmt.Println("The server is unreachable, the task is queued until `pomo sync` sends it")
+		return
+	}
 
 	//if the user requested to start the created task
-	if options.start {
-		pomoCli.Client().StartTask(taskID)
+	if options.start && options.run.Detach {
+		maybe(detach(pomoCli, taskID, options.run.Output), pomoCli.Logger())
+	} else if options.start {
+		maybe(pomoCli.Client().StartTask(taskID, options.run), pomoCli.Logger())
 	} else {
 		pomoCli.Logger().Debugf("Task id: %d created", taskID)
 	}
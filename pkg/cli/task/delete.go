@@ -1,15 +1,20 @@
 package task
 
 import (
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/joaorufino/pomo/pkg/cli"
 	"github.com/spf13/cobra"
 )
 
 type deleteOptions struct {
 	taskID int
+	force  bool
 }
 
-// NewConfigCommand returns a cobra command for `config` subcommands
+// NewTaskDeleteCommand returns a cobra command for `task delete`
 func NewTaskDeleteCommand(pomoCli cli.Cli) *cobra.Command {
 
 	options := &deleteOptions{}
@@ -17,21 +22,30 @@ func NewTaskDeleteCommand(pomoCli cli.Cli) *cobra.Command {
 	taskDeleteCmd := &cobra.Command{
 		Use:   "delete",
 		Short: "delete task",
-		Long:  `delete task using id`,
+		Long:  `move a task to the trash using its id, it can be restored until the trash is purged`,
 		Run: func(cmd *cobra.Command, args []string) {
-			delete(pomoCli, options)
+			maybe(delete(pomoCli, options, os.Stdin, os.Stdout), pomoCli.Logger())
 		},
 	}
 
 	flags := taskDeleteCmd.Flags()
 
-	flags.IntVarP(&options.taskID, "taskID", "t", -1, "ID of task to begin")
+	flags.IntVarP(&options.taskID, "taskID", "t", -1, "ID of task to delete")
+	flags.BoolVarP(&options.force, "force", "f", false, "do not ask for confirmation")
 	taskDeleteCmd.MarkFlagRequired("taskID")
 
 	return taskDeleteCmd
 }
 
-func delete(pomoCli cli.Cli, options *deleteOptions) {
-	err := pomoCli.Client().DeleteTaskByID(options.taskID)
-	maybe(err, pomoCli.Logger())
+func delete(pomoCli cli.Cli, options *deleteOptions, in io.Reader, out io.Writer) error {
+	if !options.force {
+		task, err := pomoCli.Client().GetTask(options.taskID)
+		if err != nil {
+			return err
+		}
+		if !cli.Confirm(in, out, fmt.Sprintf("Move task %d %q to the trash?", task.ID, task.Message)) {
+			return nil
+		}
+	}
+	return pomoCli.Client().DeleteTaskByID(options.taskID)
 }
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+)
+
+// detach starts the session of taskID in a background pomo
+// process without UI, its events are appended to the runner log
+func detach(pomoCli cli.Cli, taskID int, output string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	logPath := pomoCli.Config().Runner.Log
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return err
+	}
+	log, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	cmd := exec.Command(executable, detachArgs(taskID, output)...)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	cmd.SysProcAttr = detachAttr()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	fmt.Printf("Task %d started in the background (pid %d), logging to %s\n", taskID, cmd.Process.Pid, logPath)
+	fmt.Printf("Run `%s attach` to follow it\n", pomoCli.Executable())
+	return cmd.Process.Release()
+}
+
+// detachArgs returns the arguments of the background process
+func detachArgs(taskID int, output string) []string {
+	return []string{"task", "start", "--taskID", strconv.Itoa(taskID), "--no-ui", "--output", output}
+}
@@ -0,0 +1,11 @@
+package task
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDetachArgs(t *testing.T) {
+	assert.DeepEqual(t, detachArgs(7, "json"), []string{"task", "start", "--taskID", "7", "--no-ui", "--output", "json"})
+}
@@ -0,0 +1,12 @@
+package task
+
+import "syscall"
+
+// detachedProcess is the DETACHED_PROCESS creation flag
+const detachedProcess = 0x00000008
+
+// detachAttr starts the process without a console
+// so it outlives the terminal it was started from
+func detachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess}
+}
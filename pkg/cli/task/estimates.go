@@ -0,0 +1,116 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type estimatesOptions struct {
+	asJSON bool
+	weeks  int
+}
+
+// NewTaskEstimatesCommand returns a cobra command for `task estimates`
+func NewTaskEstimatesCommand(pomoCli cli.Cli) *cobra.Command {
+	options := estimatesOptions{}
+
+	taskEstimatesCmd := &cobra.Command{
+		Use:   "estimates",
+		Short: "compare the estimates with the actuals",
+		Long: `compare the pomodoros planned for the finished tasks with the time spent on them,
+per task, tag and week, flagging the tags that are chronically underestimated`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(estimates(pomoCli, &options), pomoCli.Logger())
+		},
+	}
+
+	flags := taskEstimatesCmd.Flags()
+	flags.BoolVarP(&options.asJSON, "json", "j", false, "output the report as JSON")
+	flags.IntVarP(&options.weeks, "weeks", "w", 4, "number of weeks shown")
+
+	return taskEstimatesCmd
+}
+
+func estimates(pomoCli cli.Cli, options *estimatesOptions) error {
+	tasks, err := pomoCli.Client().GetTaskList()
+	if err != nil {
+		return err
+	}
+	report := models.NewEstimateReport(*tasks, time.Now(), options.weeks)
+	if options.asJSON {
+		return json.NewEncoder(os.Stdout).Encode(&report)
+	}
+	printEstimates(os.Stdout, report)
+	return nil
+}
+
+// printEstimates prints the report, the underestimated tags in red
+func printEstimates(w io.Writer, report models.EstimateReport) {
+	fmt.Fprintf(w, "Total: %s\n", formatEstimate(report.Total))
+
+	fmt.Fprintln(w, "Tasks:")
+	for _, task := range report.Tasks {
+		fmt.Fprintf(w, "  %d: %s - %s\n", task.ID, formatEstimate(task.Estimate), task.Message)
+	}
+
+	fmt.Fprintln(w, "Tags:")
+	underestimated := map[string]bool{}
+	for _, tag := range report.Underestimated() {
+		underestimated[tag] = true
+	}
+	tags := make([]string, 0, len(report.Tags))
+	for tag := range report.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		line := fmt.Sprintf("  %s: %s", tag, formatEstimate(report.Tags[tag]))
+		if underestimated[tag] {
+			line = color.New(color.FgRed).Sprint(line + " underestimated")
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	fmt.Fprintln(w, "Weeks:")
+	for _, week := range report.Weeks {
+		fmt.Fprintf(w, "  %s: %s\n", week.Start.Format("2006-01-02"), formatEstimate(week.Estimate))
+	}
+}
+
+// formatEstimate summarises an estimate, eg: "2 tasks, 4 estimated, 5.5 spent (1.38x)"
+func formatEstimate(estimate models.Estimate) string {
+	if estimate.Tasks == 0 {
+		return "-"
+	}
+	tasks := "tasks"
+	if estimate.Tasks == 1 {
+		tasks = "task"
+	}
+	return fmt.Sprintf("%d %s, %g estimated, %.1f spent (%.2fx)",
+		estimate.Tasks, tasks, estimate.Estimated, estimate.Actual, estimate.Ratio())
+}
+
+// suggest prints how many pomodoros the history of
+// its tags suggests for the task, when it differs
+func suggest(w io.Writer, task *models.Task, tasks models.List) {
+	if len(task.Tags) == 0 {
+		return
+	}
+	report := models.NewEstimateReport(tasks, time.Now(), 0)
+	suggested, history, ok := report.Suggest(task.Tags, task.NPomodoros)
+	if !ok {
+		return
+	}
+	fmt.Fprintf(w, "Tasks tagged %s took %.2fx their estimate, consider %d pomodoros instead of %d\n",
+		strings.Join(task.Tags, ", "), history.Ratio(), suggested, task.NPomodoros)
+}
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	tasks := models.List{}
+	for i := 0; i < 3; i++ {
+		tasks = append(tasks, models.Task{
+			NPomodoros: 1, Duration: 25 * time.Minute, Tags: []string{"docs"},
+			Pomodoros: []*models.Pomodoro{{Start: start, End: start.Add(50 * time.Minute)}},
+		})
+	}
+
+	out := &bytes.Buffer{}
+	suggest(out, &models.Task{NPomodoros: 2, Tags: []string{"docs"}}, tasks)
+	assert.Equal(t, out.String(), "Tasks tagged docs took 2.00x their estimate, consider 4 pomodoros instead of 2\n")
+
+	out.Reset()
+	suggest(out, &models.Task{NPomodoros: 2, Tags: []string{"code"}}, tasks)
+	assert.Equal(t, out.String(), "", "no history")
+}
@@ -17,6 +17,7 @@ type listOptions struct {
 	all      bool
 	limit    int
 	duration string
+	project  int
 }
 
 func validateTaskListOptions(opts *listOptions) (*listOptions, error) {
@@ -54,6 +55,7 @@ func NewTaskListCommand(pomoCli cli.Cli) *cobra.Command {
 	flags.BoolVarP(&options.all, "all", "a", true, "output all tasks")
 	flags.IntVarP(&options.limit, "limit", "n", 0, "limit the number of resultsby n")
 	flags.StringVarP(&options.duration, "duration", "d", "24h", "show tasks within this duration")
+	flags.IntVarP(&options.project, "project", "P", 0, "only show the tasks of this project and its subprojects")
 
 	return taskListCmd
 }
@@ -82,6 +84,13 @@ func list(pomoCli cli.Cli, options *listOptions) error {
 	if !options.all {
 		list = models.After(time.Now().Add(-parsed), list)
 	}
+	if options.project > 0 {
+		projects, err := pomoCli.Client().GetProjects()
+		if err != nil {
+			return err
+		}
+		list = models.InProjects(list, projects.Subtree(options.project))
+	}
 	if options.limit > 0 && (len(list) > options.limit) {
 		list = list[0:options.limit]
 	}
@@ -90,7 +99,12 @@ func list(pomoCli cli.Cli, options *listOptions) error {
 			return err
 		}
 	} else {
-		runnerC.SummarizeTasks(pomoCli.Client().Config().String("server.datatimeformat"), list)
+		tags, err := pomoCli.Client().GetTags()
+		if err != nil {
+			return err
+		}
+		colors := runnerC.NewTagColors(pomoCli.Config().Colors, tags)
+		runnerC.SummarizeTasks(pomoCli.Config().Server.DatetimeFormat, colors, list)
 	}
 	return nil
 }
@@ -0,0 +1,26 @@
+package task
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewTaskRestoreCommand returns a cobra command for `task restore`
+func NewTaskRestoreCommand(pomoCli cli.Cli) *cobra.Command {
+	var taskID int
+
+	taskRestoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "restore a deleted task",
+		Long:  `take a task out of the trash with its pomodoros, see pomo trash list`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().RestoreTaskByID(taskID), pomoCli.Logger())
+		},
+	}
+
+	flags := taskRestoreCmd.Flags()
+	flags.IntVarP(&taskID, "taskID", "t", -1, "ID of task to restore")
+	taskRestoreCmd.MarkFlagRequired("taskID")
+
+	return taskRestoreCmd
+}
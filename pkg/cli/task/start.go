@@ -4,11 +4,13 @@ import (
 	"errors"
 
 	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
 	"github.com/spf13/cobra"
 )
 
 type startOptions struct {
 	taskID int
+	run    models.RunOptions
 }
 
 // NewStartCommand returns a cobra command for `config` subcommands
@@ -29,6 +31,7 @@ func NewTaskStartCommand(pomoCli cli.Cli) *cobra.Command {
 
 	flags.IntVarP(&options.taskID, "taskID", "t", -1, "ID of task to begin")
 	taskStartCmd.MarkFlagRequired("taskID")
+	addRunFlags(taskStartCmd, &options.run)
 
 	return taskStartCmd
 }
@@ -37,6 +40,23 @@ func start(pomoCli cli.Cli, options *startOptions) error {
 	if pomoCli.Client() == nil {
 		return errors.New("client not defined")
 	}
-	pomoCli.Client().StartTask(options.taskID)
-	return nil
+	if err := options.run.Validate(); err != nil {
+		return err
+	}
+	if options.run.Detach {
+		// the background process would only log the error
+		if _, err := pomoCli.Client().GetTask(options.taskID); err != nil {
+			return err
+		}
+		return detach(pomoCli, options.taskID, options.run.Output)
+	}
+	return pomoCli.Client().StartTask(options.taskID, options.run)
+}
+
+// addRunFlags adds the flags selecting how the session is driven
+func addRunFlags(cmd *cobra.Command, options *models.RunOptions) {
+	flags := cmd.Flags()
+	flags.BoolVar(&options.Headless, "no-ui", false, "run without the UI, reading controls from stdin and signals")
+	flags.StringVarP(&options.Output, "output", "o", models.OutputText, "format of the events printed without the UI (text or json)")
+	flags.BoolVar(&options.Detach, "detach", false, "run the session in the background, see attach")
 }
@@ -1,6 +1,9 @@
 package task
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/joaorufino/pomo/pkg/cli"
 	"github.com/spf13/cobra"
 
@@ -27,5 +30,18 @@ func status(pomoCli cli.Cli) error {
 		return err
 	}
 	runnerC.OutputStatus(*status)
+
+	goals, err := pomoCli.Client().GetGoals()
+	if err != nil || len(goals) == 0 {
+		return err
+	}
+	tasks, err := pomoCli.Client().GetTaskList()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, goal := range goals {
+		fmt.Println(goal.Progress(*tasks, now))
+	}
 	return nil
 }
@@ -1,8 +1,6 @@
 package task
 
 import (
-	"os"
-
 	"github.com/joaorufino/pomo/pkg/cli"
 	"github.com/joaorufino/pomo/pkg/client"
 	"github.com/joaorufino/pomo/pkg/core"
@@ -20,9 +18,15 @@ var (
 //	 ├── task
 //	 │   ├── create
 //	 │   ├── delete
+//	 │   ├── estimates
+//	 │   ├── extend
 //	 │   ├── list
+//	 │   ├── restart
+//	 │   ├── restore
+//	 │   ├── skip
 //	 │   ├── start
-//	 │   └── status
+//	 │   ├── status
+//	 │   └── stop
 //
 // /
 // NewServerCommand returns a cobra command for `server` subcommands
@@ -46,16 +50,22 @@ func NewTaskCommand(pomoCli cli.Cli) *cobra.Command {
 	taskCmd.AddCommand(
 		NewTaskCreateCommand(pomoCli),
 		NewTaskDeleteCommand(pomoCli),
+		NewTaskEstimatesCommand(pomoCli),
+		NewTaskExtendCommand(pomoCli),
 		NewTaskListCommand(pomoCli),
+		NewTaskRestartCommand(pomoCli),
+		NewTaskRestoreCommand(pomoCli),
+		NewTaskSkipCommand(pomoCli),
 		NewTaskStartCommand(pomoCli),
 		NewTaskStatusCommand(pomoCli),
+		NewTaskStopCommand(pomoCli),
 	)
 	return taskCmd
 }
 
 func maybe(err error, logger *zap.SugaredLogger) {
 	if err != nil {
-		logger.Fatalf("Error:%s\n", err)
-		os.Exit(1)
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
 	}
 }
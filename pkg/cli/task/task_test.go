@@ -0,0 +1,34 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/cli/test"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	testClient "github.com/joaorufino/pomo/pkg/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestMissingTask(t *testing.T) {
+	mockCli := test.NewMockCli()
+	mockCli.SetClient(testClient.NewMockClient(nil, testClient.MockClientOptions{
+		List: &models.List{{ID: 1, Message: "write the docs"}},
+	}))
+
+	out := &bytes.Buffer{}
+	err := delete(mockCli, &deleteOptions{taskID: 2}, strings.NewReader("y\n"), out)
+	assert.Error(t, err, "task 2 does not exist")
+	assert.Equal(t, models.TypeOf(err), models.ErrorTypeNotFound)
+	assert.Equal(t, out.Len(), 0, "nothing to confirm")
+	assert.Error(t, delete(mockCli, &deleteOptions{taskID: 2, force: true}, nil, out), "task 2 does not exist")
+
+	err = start(mockCli, &startOptions{taskID: 2, run: models.RunOptions{Output: models.OutputText, Detach: true}})
+	assert.Error(t, err, "task 2 does not exist")
+	err = start(mockCli, &startOptions{taskID: 2, run: models.RunOptions{Output: models.OutputText}})
+	assert.Error(t, err, "task 2 does not exist")
+
+	assert.NilError(t, delete(mockCli, &deleteOptions{taskID: 1}, strings.NewReader("y\n"), out))
+	assert.Equal(t, out.String(), `Move task 1 "write the docs" to the trash? [y/N] `)
+}
@@ -0,0 +1,86 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	template   models.Template
+	duration   string
+	recurrence string
+	weekday    string
+}
+
+// NewTemplateCreateCommand returns a cobra command for `template create`
+func NewTemplateCreateCommand(pomoCli cli.Cli) *cobra.Command {
+	options := createOptions{}
+
+	templateCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a task template",
+		Long:  `create a task template, with --recur the server creates its task on the days due`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(create(pomoCli, &options), pomoCli.Logger())
+		},
+	}
+
+	flags := templateCreateCmd.Flags()
+	flags.StringVarP(&options.template.Name, "name", "n", "", "name of the template")
+	flags.StringVarP(&options.template.Message, "message", "m", "", "descriptive name of the tasks")
+	flags.IntVarP(&options.template.NPomodoros, "pomodoros", "p", 4, "number of pomodoros")
+	flags.StringVarP(&options.duration, "duration", "d", "25m", "duration of each stent")
+	flags.StringSliceVarP(&options.template.Tags, "tag", "t", []string{}, "tags associated with the tasks")
+	flags.IntVarP(&options.template.ProjectID, "project", "P", 0, "ID of the project the tasks belong to")
+	flags.StringVarP(&options.recurrence, "recur", "r", "", "create the task daily, on weekdays or weekly")
+	flags.StringVarP(&options.weekday, "weekday", "w", "monday", "day of the weekly tasks")
+	templateCreateCmd.MarkFlagRequired("name")
+	templateCreateCmd.MarkFlagRequired("message")
+
+	return templateCreateCmd
+}
+
+// newTemplate returns the template described by the options
+func newTemplate(options *createOptions) (*models.Template, error) {
+	template := options.template
+	duration, err := time.ParseDuration(options.duration)
+	if err != nil {
+		return nil, err
+	}
+	template.Duration = duration
+	template.Recurrence = models.Recurrence(options.recurrence)
+	if template.Recurrence == models.RecurWeekly {
+		if template.Weekday, err = parseWeekday(options.weekday); err != nil {
+			return nil, err
+		}
+	}
+	return &template, nil
+}
+
+// parseWeekday returns the weekday named by day, eg: monday
+func parseWeekday(day string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), day) {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", day)
+}
+
+func create(pomoCli cli.Cli, options *createOptions) error {
+	template, err := newTemplate(options)
+	if err != nil {
+		return err
+	}
+	templateID, err := pomoCli.Client().CreateTemplate(template)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Template %d created\n", templateID)
+	return nil
+}
@@ -0,0 +1,26 @@
+package template
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewTemplateDeleteCommand returns a cobra command for `template delete`
+func NewTemplateDeleteCommand(pomoCli cli.Cli) *cobra.Command {
+	var templateID int
+
+	templateDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "delete a task template",
+		Long:  `delete a task template, the tasks created from it are kept`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(pomoCli.Client().DeleteTemplateByID(templateID), pomoCli.Logger())
+		},
+	}
+
+	flags := templateDeleteCmd.Flags()
+	flags.IntVarP(&templateID, "templateID", "i", -1, "ID of the template to delete")
+	templateDeleteCmd.MarkFlagRequired("templateID")
+
+	return templateDeleteCmd
+}
@@ -0,0 +1,64 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTemplateListCommand returns a cobra command for `template list`
+func NewTemplateListCommand(pomoCli cli.Cli) *cobra.Command {
+	var asJSON bool
+
+	templateListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the task templates",
+		Long:  `list the task templates and when they recur`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(list(pomoCli, asJSON), pomoCli.Logger())
+		},
+	}
+
+	flags := templateListCmd.Flags()
+	flags.BoolVarP(&asJSON, "json", "j", false, "output the templates as JSON")
+
+	return templateListCmd
+}
+
+func list(pomoCli cli.Cli, asJSON bool) error {
+	templates, err := pomoCli.Client().GetTemplates()
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return json.NewEncoder(os.Stdout).Encode(&templates)
+	}
+	printTemplates(os.Stdout, templates)
+	return nil
+}
+
+// printTemplates prints a template per line, eg:
+// 1: standup [weekdays] 1x15m - Stand-up [team]
+func printTemplates(w io.Writer, templates models.Templates) {
+	for _, template := range templates {
+		recurrence := string(template.Recurrence)
+		switch template.Recurrence {
+		case models.RecurNever:
+			recurrence = "on demand"
+		case models.RecurWeekly:
+			recurrence += " on " + template.Weekday.String()
+		}
+		fmt.Fprintf(w, "%d: %s [%s] %dx%s - %s", template.ID, template.Name, recurrence,
+			template.NPomodoros, template.Duration, template.Message)
+		if len(template.Tags) > 0 {
+			fmt.Fprintf(w, " [%s]", strings.Join(template.Tags, " "))
+		}
+		fmt.Fprintln(w)
+	}
+}
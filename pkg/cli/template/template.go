@@ -0,0 +1,50 @@
+package template
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// template command
+//
+//	pomo
+//	 ├── template
+//	 │   ├── create
+//	 │   ├── delete
+//	 │   └── list
+//
+// /
+// NewTemplateCommand returns a cobra command for `template` subcommands
+func NewTemplateCommand(pomoCli cli.Cli) *cobra.Command {
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "operations regarding the task templates",
+		Long:  "tasks created again and again, with `task create --from-template` or every day by the server",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	templateCmd.AddCommand(
+		NewTemplateCreateCommand(pomoCli),
+		NewTemplateDeleteCommand(pomoCli),
+		NewTemplateListCommand(pomoCli),
+	)
+	return templateCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -0,0 +1,29 @@
+package trash
+
+import (
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewTrashEmptyCommand returns a cobra command for `trash empty`
+func NewTrashEmptyCommand(pomoCli cli.Cli) *cobra.Command {
+	var force bool
+
+	trashEmptyCmd := &cobra.Command{
+		Use:   "empty",
+		Short: "empty the trash",
+		Long:  `permanently delete the tasks in the trash with their pomodoros and notes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if force || cli.Confirm(os.Stdin, os.Stdout, "Permanently delete the tasks in the trash?") {
+				maybe(pomoCli.Client().EmptyTrash(), pomoCli.Logger())
+			}
+		},
+	}
+
+	flags := trashEmptyCmd.Flags()
+	flags.BoolVarP(&force, "force", "f", false, "do not ask for confirmation")
+
+	return trashEmptyCmd
+}
@@ -0,0 +1,55 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/spf13/cobra"
+)
+
+// NewTrashListCommand returns a cobra command for `trash list`
+func NewTrashListCommand(pomoCli cli.Cli) *cobra.Command {
+	var asJSON bool
+
+	trashListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the deleted tasks",
+		Long:  `list the tasks in the trash, last deleted first`,
+		Run: func(cmd *cobra.Command, args []string) {
+			maybe(list(pomoCli, asJSON), pomoCli.Logger())
+		},
+	}
+
+	flags := trashListCmd.Flags()
+	flags.BoolVarP(&asJSON, "json", "j", false, "output the deleted tasks as JSON")
+
+	return trashListCmd
+}
+
+func list(pomoCli cli.Cli, asJSON bool) error {
+	trash, err := pomoCli.Client().GetTrash()
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return json.NewEncoder(os.Stdout).Encode(trash)
+	}
+	printTrash(os.Stdout, pomoCli.Config().Server.DatetimeFormat, *trash)
+	return nil
+}
+
+// printTrash prints a deleted task per line, eg:
+// 3: [2/4] write the report, deleted 2021-01-16 19:05
+func printTrash(w io.Writer, datetimeFormat string, trash models.List) {
+	for _, task := range trash {
+		fmt.Fprintf(w, "%d: [%d/%d] %s", task.ID, len(task.Pomodoros), task.NPomodoros, task.Message)
+		if task.DeletedAt != nil {
+			fmt.Fprintf(w, ", deleted %s", task.DeletedAt.Format(datetimeFormat))
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,20 @@
+package trash
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintTrash(t *testing.T) {
+	deleted := time.Date(2021, 1, 16, 19, 5, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	printTrash(buf, "2006-01-02 15:04", models.List{
+		{ID: 3, Message: "write the report", NPomodoros: 4, Pomodoros: make([]*models.Pomodoro, 2), DeletedAt: &deleted},
+		{ID: 1, Message: "inbox zero", NPomodoros: 1},
+	})
+	assert.Equal(t, buf.String(), "3: [2/4] write the report, deleted 2021-01-16 19:05\n1: [0/1] inbox zero\n")
+}
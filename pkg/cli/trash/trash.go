@@ -0,0 +1,48 @@
+package trash
+
+import (
+	"github.com/joaorufino/pomo/pkg/cli"
+	"github.com/joaorufino/pomo/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// trash command
+//
+//	pomo
+//	 ├── trash
+//	 │   ├── empty
+//	 │   └── list
+//
+// /
+// NewTrashCommand returns a cobra command for `trash` subcommands
+func NewTrashCommand(pomoCli cli.Cli) *cobra.Command {
+	trashCmd := &cobra.Command{
+		Use:   "trash",
+		Short: "operations regarding the deleted tasks",
+		Long:  "deleted tasks are kept in the trash until it is emptied or their retention period ends, `task restore` takes them out",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			c, err := client.NewClient(pomoCli.Config())
+			maybe(err, pomoCli.Logger())
+			pomoCli.SetClient(&c)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			pomoCli.Client().Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	trashCmd.AddCommand(
+		NewTrashEmptyCommand(pomoCli),
+		NewTrashListCommand(pomoCli),
+	)
+	return trashCmd
+}
+
+func maybe(err error, logger *zap.SugaredLogger) {
+	if err != nil {
+		logger.Debugw("Command failed", "error", err)
+		cli.Fail(err)
+	}
+}
@@ -0,0 +1,229 @@
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// ErrUnreachable is returned by a replay that
+// stopped because the server could not be reached
+var ErrUnreachable = errors.New("server unreachable")
+
+// Journal keeps the writes the client could not send
+// to the server in a file until they are replayed, the
+// file is locked so that several processes can share it
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// state is the content of the journal file
+type state struct {
+	Requests []models.QueuedRequest `json:"requests"`
+	// Last ID given to a queued task, the tasks created
+	// while the server is unreachable get negative IDs
+	LastTaskID int `json:"last_task_id"`
+	// Server IDs of the replayed tasks by their queued ID
+	TaskIDs map[int]int `json:"task_ids"`
+}
+
+// New creates a journal kept in the file at path
+func New(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// NewKey returns a random idempotency key
+func NewKey() string {
+	key := make([]byte, 16)
+	_, _ = rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// Requests returns the queued requests, oldest first
+func (j *Journal) Requests() ([]models.QueuedRequest, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	unlock, err := j.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	current, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	return current.Requests, nil
+}
+
+// Add queues the request, a task is given a negative ID
+// which is returned, a status replaces the queued ones
+func (j *Journal) Add(request models.QueuedRequest) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	unlock, err := j.lock()
+	if err != nil {
+		return -1, err
+	}
+	defer unlock()
+	current, err := j.load()
+	if err != nil {
+		return -1, err
+	}
+	id := 0
+	switch request.Kind {
+	case models.QueuedTask:
+		current.LastTaskID--
+		id = current.LastTaskID
+		request.TaskID = id
+	case models.QueuedStatus:
+		// only the last status of the session matters
+		requests := current.Requests[:0]
+		for _, queued := range current.Requests {
+			if queued.Kind != models.QueuedStatus {
+				requests = append(requests, queued)
+			}
+		}
+		current.Requests = requests
+	}
+	current.Requests = append(current.Requests, request)
+	return id, j.save(current)
+}
+
+// Replay sends the queued requests in order, send returns the
+// server ID of a task, a request the server rejects is dropped
+// and reported in the error, it stops at the first error the
+// request could succeed after, ErrUnreachable or a fault of the
+// server, and returns the number of requests sent
+func (j *Journal) Replay(send func(models.QueuedRequest) (int, error)) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	unlock, err := j.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	current, err := j.load()
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	var rejected []error
+	for len(current.Requests) > 0 {
+		request := current.Requests[0]
+		if request.Kind == models.QueuedPomodoro && request.TaskID < 0 {
+			taskID, ok := current.TaskIDs[request.TaskID]
+			if !ok {
+				rejected = append(rejected, fmt.Errorf("%s %s: task %d was never created", request.Kind, request.Key, request.TaskID))
+				current.Requests = current.Requests[1:]
+				continue
+			}
+			request.TaskID = taskID
+		}
+		taskID, err := send(request)
+		if Retryable(err) {
+			rejected = append(rejected, err)
+			break
+		}
+		if err != nil {
+			rejected = append(rejected, fmt.Errorf("%s %s: %w", request.Kind, request.Key, err))
+		} else {
+			sent++
+			if request.Kind == models.QueuedTask {
+				current.TaskIDs[request.TaskID] = taskID
+			}
+		}
+		current.Requests = current.Requests[1:]
+	}
+	if len(current.Requests) == 0 {
+		// nothing refers to the queued task IDs anymore
+		current = &state{TaskIDs: map[int]int{}}
+	}
+	if err := j.save(current); err != nil {
+		return sent, err
+	}
+	return sent, errors.Join(rejected...)
+}
+
+// Retryable is true when the request failed for a reason other
+// than the request itself and has to be kept for the next replay
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrUnreachable) {
+		return true
+	}
+	switch models.TypeOf(err) {
+	case models.ErrorTypeInvalid, models.ErrorTypeNotFound, models.ErrorTypeDuplicate:
+		return false
+	}
+	return true
+}
+
+// lock blocks until no other process uses the journal,
+// it returns the function releasing the lock
+func (j *Journal) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(j.path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", file.Name(), err)
+	}
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+func (j *Journal) load() (*state, error) {
+	current := &state{}
+	raw, err := os.ReadFile(j.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, current); err != nil {
+			return nil, fmt.Errorf("%s: %w", j.path, err)
+		}
+	}
+	if current.TaskIDs == nil {
+		current.TaskIDs = map[int]int{}
+	}
+	return current, nil
+}
+
+// save replaces the file so that it is never left half written
+func (j *Journal) save(current *state) error {
+	if len(current.Requests) == 0 && len(current.TaskIDs) == 0 {
+		err := os.Remove(j.path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	raw, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestReplay(t *testing.T) {
+	file := path.Join(t.TempDir(), "journal.json")
+	j := New(file)
+	start := time.Now().Add(-time.Hour)
+
+	taskID, err := j.Add(models.QueuedRequest{Key: NewKey(), Kind: models.QueuedTask, Task: &models.Task{Message: "offline"}})
+	assert.NilError(t, err)
+	assert.Equal(t, taskID, -1)
+	for _, request := range []models.QueuedRequest{
+		{Key: NewKey(), Kind: models.QueuedStatus, Status: &models.Status{State: models.RUNNING}},
+		{Key: NewKey(), Kind: models.QueuedPomodoro, TaskID: taskID, Pomodoro: &models.Pomodoro{Start: start, End: start.Add(time.Minute)}},
+		{Key: NewKey(), Kind: models.QueuedPomodoro, TaskID: 7, Pomodoro: &models.Pomodoro{Start: start, End: start.Add(time.Minute)}},
+		{Key: NewKey(), Kind: models.QueuedStatus, Status: &models.Status{State: models.COMPLETE}},
+	} {
+		_, err = j.Add(request)
+		assert.NilError(t, err)
+	}
+	queue, err := New(file).Requests()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(queue, 4), "the statuses are coalesced")
+	assert.Equal(t, queue[3].Status.State, models.COMPLETE)
+
+	sent, err := j.Replay(func(models.QueuedRequest) (int, error) {
+		return -1, ErrUnreachable
+	})
+	assert.Check(t, errors.Is(err, ErrUnreachable))
+	assert.Equal(t, sent, 0)
+	queue, err = j.Requests()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(queue, 4), "kept while unreachable")
+
+	for _, errorType := range []models.ErrorType{models.ErrorTypeInternal, models.ErrorTypeQuery, models.ErrorTypeUnavailable} {
+		sent, err = j.Replay(func(models.QueuedRequest) (int, error) {
+			return -1, models.NewError(errorType, "server fault")
+		})
+		assert.Check(t, is.ErrorContains(err, "server fault"))
+		assert.Equal(t, sent, 0)
+		queue, err = j.Requests()
+		assert.NilError(t, err)
+		assert.Check(t, is.Len(queue, 4), "kept on a %s error", errorType)
+	}
+
+	replayed := []models.QueuedRequest{}
+	sent, err = j.Replay(func(request models.QueuedRequest) (int, error) {
+		replayed = append(replayed, request)
+		if request.TaskID == 7 {
+			return -1, models.NewError(models.ErrorTypeNotFound, "task 7 does not exist")
+		}
+		return 42, nil
+	})
+	assert.ErrorContains(t, err, "task 7 does not exist")
+	assert.Equal(t, sent, 3)
+	assert.Assert(t, is.Len(replayed, 4))
+	assert.Equal(t, replayed[1].TaskID, 42, "the queued task ID is mapped")
+	assert.Equal(t, replayed[0].Key, queue[0].Key, "replayed with the same key")
+
+	queue, err = j.Requests()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(queue, 0), "rejected requests are dropped")
+	_, err = os.Stat(file)
+	assert.Check(t, os.IsNotExist(err), "an empty journal is removed")
+}
+
+func TestConcurrentWriters(t *testing.T) {
+	file := path.Join(t.TempDir(), "journal.json")
+	const writers, requests = 4, 25
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// a journal per writer, as each process opens its own
+			j := New(file)
+			for i := 0; i < requests; i++ {
+				_, err := j.Add(models.QueuedRequest{Key: NewKey(), Kind: models.QueuedTask, Task: &models.Task{Message: "offline"}})
+				assert.Check(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	queue, err := New(file).Requests()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(queue, writers*requests), "no queued request is lost")
+	ids := map[int]bool{}
+	for _, request := range queue {
+		ids[request.TaskID] = true
+	}
+	assert.Check(t, is.Len(ids, writers*requests), "every task gets its own ID")
+}
@@ -3,11 +3,13 @@ package rest
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/joaorufino/pomo/pkg/client/journal"
 	"github.com/joaorufino/pomo/pkg/core/models"
 	"github.com/joaorufino/pomo/pkg/runner"
 	"github.com/spf13/viper"
@@ -21,6 +23,9 @@ type RestClient struct {
 	path       string
 	logger     *zap.SugaredLogger
 	HTTPClient *http.Client
+	// queues the writes while the server is unreachable,
+	// nil when client.journal is not set
+	journal *journal.Journal
 }
 
 // add requestHeaders
@@ -35,56 +40,222 @@ func addHeaders(req *http.Request) {
 func (c RestClient) makeRequest(req *http.Request, payload interface{}) error {
 	addHeaders(req)
 	res, err := c.HTTPClient.Do(req)
-	maybe(err, c.logger)
+	if err != nil {
+		return &models.Error{Type: models.ErrorTypeUnavailable, Err: fmt.Errorf("%w: %v", journal.ErrUnreachable, err)}
+	}
 
 	defer res.Body.Close()
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		var errRes models.Error
-		if err = json.NewDecoder(res.Body).Decode(&errRes); err == nil {
-			return errRes.Err
-		}
-
-		return fmt.Errorf("unknown error, status code: %d", res.StatusCode)
+		return responseError(res)
 	}
-	if payload != nil {
+	if payload != nil && res.StatusCode != http.StatusNoContent {
 		err = json.NewDecoder(res.Body).Decode(payload)
 	}
 	return err
 }
 
-// createTask requests the creation of a task
+// responseError returns the typed error the server answered,
+// the type follows the status code when the server omits it
+func responseError(res *http.Response) error {
+	var errRes struct {
+		Error string `json:"error"`
+		Type  string `json:"type"`
+	}
+	_ = json.NewDecoder(res.Body).Decode(&errRes)
+	if errRes.Error == "" {
+		errRes.Error = fmt.Sprintf("status code %d", res.StatusCode)
+	}
+	errorType := models.ParseErrorType(errRes.Type)
+	if errRes.Type == "" {
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			errorType = models.ErrorTypeNotFound
+		case http.StatusBadRequest:
+			errorType = models.ErrorTypeInvalid
+		case http.StatusConflict:
+			errorType = models.ErrorTypeDuplicate
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			errorType = models.ErrorTypeUnavailable
+		}
+	}
+	return &models.Error{Type: errorType, Err: errors.New(errRes.Error)}
+}
+
+// createTask requests the creation of a task, it is queued
+// with a negative ID when the server is unreachable
 func (c RestClient) CreateTask(task *models.Task) (int, error) {
-	body, err := json.Marshal(task)
+	return c.queueable(models.QueuedRequest{Kind: models.QueuedTask, Task: task})
+}
+
+// CreatePomodoro requests the server
+// to append a pomodoro to a task
+func (c RestClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
+	_, err := c.queueable(models.QueuedRequest{Kind: models.QueuedPomodoro, TaskID: taskID, Pomodoro: &pomodoro})
+	return err
+}
+
+// queueable sends the request, or queues it in the journal
+// when the server is unreachable so that it is replayed later
+func (c RestClient) queueable(request models.QueuedRequest) (int, error) {
+	request.Key = journal.NewKey()
+	request.Time = time.Now()
+	if c.journal == nil {
+		return c.send(request)
+	}
+	// the queued requests are sent first to keep the order
+	if _, err := c.FlushQueue(); journal.Retryable(err) {
+		c.logger.Debugw("Server unavailable, queued the request", "kind", request.Kind, "key", request.Key, "error", err)
+		return c.journal.Add(request)
+	} else if err != nil {
+		c.logger.Warnw("Dropped queued requests", "error", err)
+	}
+	id, err := c.send(request)
+	if journal.Retryable(err) {
+		c.logger.Debugw("Server unavailable, queued the request", "kind", request.Kind, "key", request.Key, "error", err)
+		return c.journal.Add(request)
+	}
+	return id, err
+}
+
+// send makes the request of a queued call with its
+// idempotency key and returns the ID of a created task
+func (c RestClient) send(request models.QueuedRequest) (int, error) {
+	var (
+		path string
+		body interface{}
+	)
+	switch request.Kind {
+	case models.QueuedTask:
+		path, body = "/tasks", request.Task
+	case models.QueuedPomodoro:
+		path, body = fmt.Sprintf("/tasks/%d/pomodoros", request.TaskID), request.Pomodoro
+	case models.QueuedStatus:
+		path, body = "/status", request.Status
+	default:
+		return -1, models.NewError(models.ErrorTypeInvalid, "unknown queued request %q", request.Kind)
+	}
+	raw, err := json.Marshal(body)
 	if err != nil {
 		return -1, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tasks", c.path), bytes.NewBuffer(body))
+	req, err := http.NewRequest("POST", c.path+path, bytes.NewBuffer(raw))
 	if err != nil {
 		return -1, err
 	}
+	req.Header.Set(models.IdempotencyKeyHeader, request.Key)
 	response := &models.Task{}
-	err = c.makeRequest(req, response)
-	maybe(err, c.logger)
+	if request.Kind != models.QueuedTask {
+		return 0, c.makeRequest(req, nil)
+	}
+	if err := c.makeRequest(req, response); err != nil {
+		return -1, err
+	}
 	return response.ID, nil
+}
 
+// GetQueue returns the requests queued
+// while the server was unreachable
+func (c RestClient) GetQueue() ([]models.QueuedRequest, error) {
+	if c.journal == nil {
+		return []models.QueuedRequest{}, nil
+	}
+	return c.journal.Requests()
 }
 
-// CreatePomodoro requests the server
-// to append a pomodoro to a task
-func (c RestClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
+// FlushQueue replays the queued requests
+// and returns how many were sent
+func (c RestClient) FlushQueue() (int, error) {
+	if c.journal == nil {
+		return 0, nil
+	}
+	return c.journal.Replay(c.send)
+}
+
+// RestoreTaskByID requests the server
+// to take a task out of the trash
+func (c RestClient) RestoreTaskByID(taskID int) error {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tasks/%d/restore", c.path, taskID), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// GetTrash requests the server to
+// provide the tasks in the trash
+func (c RestClient) GetTrash() (*models.List, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/trash", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.ListResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return &response.Results, nil
+}
+
+// EmptyTrash requests the server to permanently
+// delete the tasks in the trash
+func (c RestClient) EmptyTrash() error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/trash", c.path), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// GetPomodoros requests the server to provide
+// the pomodoros matching the query
+func (c RestClient) GetPomodoros(query models.PomodoroQuery) ([]*models.Pomodoro, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/pomodoros?%s", c.path, query.Values().Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.PomodoroResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+// GetPomodoro requests the server
+// to provide a single pomodoro
+func (c RestClient) GetPomodoro(pomodoroID int) (*models.Pomodoro, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/pomodoros/%d", c.path, pomodoroID), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.Pomodoro{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
 
+// UpdatePomodoro requests the server to
+// correct the times or the note of a pomodoro
+func (c RestClient) UpdatePomodoro(pomodoro models.Pomodoro) error {
 	body, err := json.Marshal(pomodoro)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/pomodoros/%d", c.path, taskID), bytes.NewBuffer(body))
+	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/pomodoros/%d", c.path, pomodoro.ID), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
+	return c.makeRequest(req, nil)
+}
 
-	err = c.makeRequest(req, nil)
-	return err
+// DeletePomodoroByID requests the server
+// to delete a single pomodoro
+func (c RestClient) DeletePomodoroByID(pomodoroID int) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/pomodoros/%d", c.path, pomodoroID), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
 }
 
 // DeleteTaskByID requests the server
@@ -95,7 +266,14 @@ func (c RestClient) DeleteTaskByID(taskID int) error {
 		return err
 	}
 
-	err = c.makeRequest(req, nil)
+	return taskError(taskID, c.makeRequest(req, nil))
+}
+
+// taskError names the task the server did not find
+func taskError(taskID int, err error) error {
+	if models.TypeOf(err) == models.ErrorTypeNotFound {
+		return models.NewError(models.ErrorTypeNotFound, "task %d does not exist", taskID)
+	}
 	return err
 }
 
@@ -109,8 +287,9 @@ func (c RestClient) GetServerStatus() (*models.Status, error) {
 	}
 
 	response := &models.Status{}
-	err = c.makeRequest(req, response)
-	maybe(err, c.logger)
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
 	return response, nil
 }
 
@@ -124,8 +303,9 @@ func (c RestClient) GetTaskList() (*models.List, error) {
 	}
 
 	response := &models.ListResults{}
-	err = c.makeRequest(req, response)
-	maybe(err, c.logger)
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
 	return &response.Results, nil
 }
 
@@ -139,36 +319,264 @@ func (c RestClient) GetTask(taskID int) (*models.Task, error) {
 	}
 
 	response := &models.Task{}
-	err = c.makeRequest(req, response)
-	maybe(err, c.logger)
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, taskError(taskID, err)
+	}
 	return response, nil
 }
 
 // StartTask starts a pomodoro
-func (c RestClient) StartTask(taskID int) error {
+func (c RestClient) StartTask(taskID int, options models.RunOptions) error {
 	task, err := c.GetTask(taskID)
-	maybe(err, c.logger)
+	if err != nil {
+		return err
+	}
 	r, err := runner.NewRunner(c, task)
-	maybe(err, c.logger)
+	if err != nil {
+		return err
+	}
 	r.Start()
+	if options.Headless {
+		return r.StartHeadless(os.Stdin, os.Stdout, options.Output)
+	}
 	r.StartUI()
 	return nil
 }
 
 // UpdateStatus sends a status update to the server
 func (c RestClient) UpdateStatus(status *models.Status) error {
+	_, err := c.queueable(models.QueuedRequest{Kind: models.QueuedStatus, Status: status})
+	return err
+}
 
-	body, err := json.Marshal(status)
+// SendControl asks the server to relay a
+// control to the running session
+func (c RestClient) SendControl(control models.Control) error {
+	body, err := json.Marshal(control)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/status", c.path), bytes.NewBuffer(body))
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/status/control", c.path), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 
-	err = c.makeRequest(req, nil)
-	return err
+	return c.makeRequest(req, nil)
+}
+
+// GetControl takes the next control relayed
+// by the server, nil when there is none
+func (c RestClient) GetControl() (*models.Control, error) {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/status/control", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.Control{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	if response.Action == "" {
+		return nil, nil
+	}
+	return response, nil
+}
+
+// CreateGoal requests the creation of a goal
+func (c RestClient) CreateGoal(goal *models.Goal) (int, error) {
+	body, err := json.Marshal(goal)
+	if err != nil {
+		return -1, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/goals", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return -1, err
+	}
+	response := &models.Goal{}
+	if err = c.makeRequest(req, response); err != nil {
+		return -1, err
+	}
+	return response.ID, nil
+}
+
+// GetGoals requests the server
+// to provide the list of goals
+func (c RestClient) GetGoals() ([]models.Goal, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/goals", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.GoalResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+// DeleteGoalByID requests the server
+// to delete a goal
+func (c RestClient) DeleteGoalByID(goalID int) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/goals/%d", c.path, goalID), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// CreateProject requests the creation of a project
+func (c RestClient) CreateProject(project *models.Project) (int, error) {
+	body, err := json.Marshal(project)
+	if err != nil {
+		return -1, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/projects", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return -1, err
+	}
+	response := &models.Project{}
+	if err = c.makeRequest(req, response); err != nil {
+		return -1, err
+	}
+	return response.ID, nil
+}
+
+// GetProjects requests the server
+// to provide the list of projects
+func (c RestClient) GetProjects() (models.Projects, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/projects", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.ProjectResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+// ArchiveProject requests the server to archive
+// a project and the projects nested under it
+func (c RestClient) ArchiveProject(projectID int) error {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/projects/%d/archive", c.path, projectID), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// GetTags requests the server
+// to provide the list of tags
+func (c RestClient) GetTags() (models.Tags, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tags", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.TagResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+// SetTagColor requests the server
+// to set the color of a tag
+func (c RestClient) SetTagColor(tag models.Tag) error {
+	body, err := json.Marshal(tag)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tags", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// RenameTags requests the server to rename
+// tags or to merge them into one
+func (c RestClient) RenameTags(rename models.TagRename) error {
+	body, err := json.Marshal(rename)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tags/rename", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// CreateTemplate requests the creation of a task template
+func (c RestClient) CreateTemplate(template *models.Template) (int, error) {
+	body, err := json.Marshal(template)
+	if err != nil {
+		return -1, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/templates", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return -1, err
+	}
+	response := &models.Template{}
+	if err = c.makeRequest(req, response); err != nil {
+		return -1, err
+	}
+	return response.ID, nil
+}
+
+// GetTemplates requests the server
+// to provide the list of templates
+func (c RestClient) GetTemplates() (models.Templates, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/templates", c.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &models.TemplateResults{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
+
+// DeleteTemplateByID requests the server
+// to delete a template
+func (c RestClient) DeleteTemplateByID(templateID int) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/templates/%d", c.path, templateID), nil)
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// AddNote requests a note to be added
+// to a task or one of its pomodoros
+func (c RestClient) AddNote(taskID int, note models.Note) error {
+	body, err := json.Marshal(note)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/tasks/%d/notes", c.path, taskID), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	return c.makeRequest(req, nil)
+}
+
+// Sync requests the server to exchange the tasks
+// and pomodoros with the pomo server at remote
+func (c RestClient) Sync(remote string) (*models.SyncReport, error) {
+	body, err := json.Marshal(models.SyncRemote{Remote: remote})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/sync/remote", c.path), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	response := &models.SyncReport{}
+	if err = c.makeRequest(req, response); err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 func (c RestClient) Close() error {
@@ -178,18 +586,15 @@ func (c RestClient) Close() error {
 
 func (c RestClient) Init() (*RestClient, error) {
 
-	return &RestClient{
+	client := &RestClient{
 		HTTPClient: &http.Client{
 			Timeout: 5 * time.Minute,
 		},
 		logger: zap.S().With("package", "restclient"),
 		path:   viper.GetString("server.path"),
-	}, nil
-}
-
-func maybe(err error, logger *zap.SugaredLogger) {
-	if err != nil {
-		logger.Fatalf("Error:\n%s\n", err)
-		os.Exit(1)
 	}
+	if path := viper.GetString("client.journal"); path != "" {
+		client.journal = journal.New(path)
+	}
+	return client, nil
 }
@@ -39,6 +39,34 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AddNote mocks base method.
+func (m *MockClient) AddNote(taskID int, note models.Note) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", taskID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockClientMockRecorder) AddNote(taskID, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockClient)(nil).AddNote), taskID, note)
+}
+
+// ArchiveProject mocks base method.
+func (m *MockClient) ArchiveProject(projectID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProject", projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveProject indicates an expected call of ArchiveProject.
+func (mr *MockClientMockRecorder) ArchiveProject(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProject", reflect.TypeOf((*MockClient)(nil).ArchiveProject), projectID)
+}
+
 // Close mocks base method.
 func (m *MockClient) Close() error {
 	m.ctrl.T.Helper()
@@ -67,6 +95,21 @@ func (mr *MockClientMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockClient)(nil).Config))
 }
 
+// CreateGoal mocks base method.
+func (m *MockClient) CreateGoal(goal *models.Goal) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", goal)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockClientMockRecorder) CreateGoal(goal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockClient)(nil).CreateGoal), goal)
+}
+
 // CreatePomodoro mocks base method.
 func (m *MockClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
 	m.ctrl.T.Helper()
@@ -81,6 +124,21 @@ func (mr *MockClientMockRecorder) CreatePomodoro(taskID, pomodoro any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePomodoro", reflect.TypeOf((*MockClient)(nil).CreatePomodoro), taskID, pomodoro)
 }
 
+// CreateProject mocks base method.
+func (m *MockClient) CreateProject(project *models.Project) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockClientMockRecorder) CreateProject(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockClient)(nil).CreateProject), project)
+}
+
 // CreateTask mocks base method.
 func (m *MockClient) CreateTask(task *models.Task) (int, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +154,49 @@ func (mr *MockClientMockRecorder) CreateTask(task any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockClient)(nil).CreateTask), task)
 }
 
+// CreateTemplate mocks base method.
+func (m *MockClient) CreateTemplate(template *models.Template) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockClientMockRecorder) CreateTemplate(template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockClient)(nil).CreateTemplate), template)
+}
+
+// DeleteGoalByID mocks base method.
+func (m *MockClient) DeleteGoalByID(goalID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoalByID", goalID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoalByID indicates an expected call of DeleteGoalByID.
+func (mr *MockClientMockRecorder) DeleteGoalByID(goalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoalByID", reflect.TypeOf((*MockClient)(nil).DeleteGoalByID), goalID)
+}
+
+// DeletePomodoroByID mocks base method.
+func (m *MockClient) DeletePomodoroByID(pomodoroID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePomodoroByID", pomodoroID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePomodoroByID indicates an expected call of DeletePomodoroByID.
+func (mr *MockClientMockRecorder) DeletePomodoroByID(pomodoroID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePomodoroByID", reflect.TypeOf((*MockClient)(nil).DeletePomodoroByID), pomodoroID)
+}
+
 // DeleteTaskByID mocks base method.
 func (m *MockClient) DeleteTaskByID(taskID int) error {
 	m.ctrl.T.Helper()
@@ -110,6 +211,139 @@ func (mr *MockClientMockRecorder) DeleteTaskByID(taskID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByID", reflect.TypeOf((*MockClient)(nil).DeleteTaskByID), taskID)
 }
 
+// DeleteTemplateByID mocks base method.
+func (m *MockClient) DeleteTemplateByID(templateID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateByID", templateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplateByID indicates an expected call of DeleteTemplateByID.
+func (mr *MockClientMockRecorder) DeleteTemplateByID(templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateByID", reflect.TypeOf((*MockClient)(nil).DeleteTemplateByID), templateID)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockClient) EmptyTrash() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockClientMockRecorder) EmptyTrash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockClient)(nil).EmptyTrash))
+}
+
+// FlushQueue mocks base method.
+func (m *MockClient) FlushQueue() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushQueue")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushQueue indicates an expected call of FlushQueue.
+func (mr *MockClientMockRecorder) FlushQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushQueue", reflect.TypeOf((*MockClient)(nil).FlushQueue))
+}
+
+// GetControl mocks base method.
+func (m *MockClient) GetControl() (*models.Control, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControl")
+	ret0, _ := ret[0].(*models.Control)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControl indicates an expected call of GetControl.
+func (mr *MockClientMockRecorder) GetControl() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControl", reflect.TypeOf((*MockClient)(nil).GetControl))
+}
+
+// GetGoals mocks base method.
+func (m *MockClient) GetGoals() ([]models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoals")
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoals indicates an expected call of GetGoals.
+func (mr *MockClientMockRecorder) GetGoals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoals", reflect.TypeOf((*MockClient)(nil).GetGoals))
+}
+
+// GetPomodoro mocks base method.
+func (m *MockClient) GetPomodoro(pomodoroID int) (*models.Pomodoro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPomodoro", pomodoroID)
+	ret0, _ := ret[0].(*models.Pomodoro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPomodoro indicates an expected call of GetPomodoro.
+func (mr *MockClientMockRecorder) GetPomodoro(pomodoroID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPomodoro", reflect.TypeOf((*MockClient)(nil).GetPomodoro), pomodoroID)
+}
+
+// GetPomodoros mocks base method.
+func (m *MockClient) GetPomodoros(query models.PomodoroQuery) ([]*models.Pomodoro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPomodoros", query)
+	ret0, _ := ret[0].([]*models.Pomodoro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPomodoros indicates an expected call of GetPomodoros.
+func (mr *MockClientMockRecorder) GetPomodoros(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPomodoros", reflect.TypeOf((*MockClient)(nil).GetPomodoros), query)
+}
+
+// GetProjects mocks base method.
+func (m *MockClient) GetProjects() (models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects")
+	ret0, _ := ret[0].(models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockClientMockRecorder) GetProjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockClient)(nil).GetProjects))
+}
+
+// GetQueue mocks base method.
+func (m *MockClient) GetQueue() ([]models.QueuedRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue")
+	ret0, _ := ret[0].([]models.QueuedRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockClientMockRecorder) GetQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockClient)(nil).GetQueue))
+}
+
 // GetServerStatus mocks base method.
 func (m *MockClient) GetServerStatus() (*models.Status, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +359,36 @@ func (mr *MockClientMockRecorder) GetServerStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerStatus", reflect.TypeOf((*MockClient)(nil).GetServerStatus))
 }
 
+// GetTags mocks base method.
+func (m *MockClient) GetTags() (models.Tags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags")
+	ret0, _ := ret[0].(models.Tags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockClientMockRecorder) GetTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockClient)(nil).GetTags))
+}
+
+// GetTask mocks base method.
+func (m *MockClient) GetTask(taskID int) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", taskID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockClientMockRecorder) GetTask(taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockClient)(nil).GetTask), taskID)
+}
+
 // GetTaskList mocks base method.
 func (m *MockClient) GetTaskList() (*models.List, error) {
 	m.ctrl.T.Helper()
@@ -140,18 +404,133 @@ func (mr *MockClientMockRecorder) GetTaskList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskList", reflect.TypeOf((*MockClient)(nil).GetTaskList))
 }
 
+// GetTemplates mocks base method.
+func (m *MockClient) GetTemplates() (models.Templates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates")
+	ret0, _ := ret[0].(models.Templates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockClientMockRecorder) GetTemplates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockClient)(nil).GetTemplates))
+}
+
+// GetTrash mocks base method.
+func (m *MockClient) GetTrash() (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash")
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockClientMockRecorder) GetTrash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockClient)(nil).GetTrash))
+}
+
+// RenameTags mocks base method.
+func (m *MockClient) RenameTags(rename models.TagRename) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTags", rename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTags indicates an expected call of RenameTags.
+func (mr *MockClientMockRecorder) RenameTags(rename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTags", reflect.TypeOf((*MockClient)(nil).RenameTags), rename)
+}
+
+// RestoreTaskByID mocks base method.
+func (m *MockClient) RestoreTaskByID(taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTaskByID", taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTaskByID indicates an expected call of RestoreTaskByID.
+func (mr *MockClientMockRecorder) RestoreTaskByID(taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTaskByID", reflect.TypeOf((*MockClient)(nil).RestoreTaskByID), taskID)
+}
+
+// SendControl mocks base method.
+func (m *MockClient) SendControl(control models.Control) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendControl", control)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendControl indicates an expected call of SendControl.
+func (mr *MockClientMockRecorder) SendControl(control any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendControl", reflect.TypeOf((*MockClient)(nil).SendControl), control)
+}
+
+// SetTagColor mocks base method.
+func (m *MockClient) SetTagColor(tag models.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTagColor", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTagColor indicates an expected call of SetTagColor.
+func (mr *MockClientMockRecorder) SetTagColor(tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTagColor", reflect.TypeOf((*MockClient)(nil).SetTagColor), tag)
+}
+
 // StartTask mocks base method.
-func (m *MockClient) StartTask(taskID int) error {
+func (m *MockClient) StartTask(taskID int, options models.RunOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartTask", taskID)
+	ret := m.ctrl.Call(m, "StartTask", taskID, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StartTask indicates an expected call of StartTask.
-func (mr *MockClientMockRecorder) StartTask(taskID any) *gomock.Call {
+func (mr *MockClientMockRecorder) StartTask(taskID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTask", reflect.TypeOf((*MockClient)(nil).StartTask), taskID, options)
+}
+
+// Sync mocks base method.
+func (m *MockClient) Sync(remote string) (*models.SyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", remote)
+	ret0, _ := ret[0].(*models.SyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockClientMockRecorder) Sync(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockClient)(nil).Sync), remote)
+}
+
+// UpdatePomodoro mocks base method.
+func (m *MockClient) UpdatePomodoro(pomodoro models.Pomodoro) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePomodoro", pomodoro)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePomodoro indicates an expected call of UpdatePomodoro.
+func (mr *MockClientMockRecorder) UpdatePomodoro(pomodoro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTask", reflect.TypeOf((*MockClient)(nil).StartTask), taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePomodoro", reflect.TypeOf((*MockClient)(nil).UpdatePomodoro), pomodoro)
 }
 
 // UpdateStatus mocks base method.
@@ -2,7 +2,6 @@ package unix
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -23,189 +22,330 @@ type UnixClient struct {
 
 // makeRequest sends a message to the server
 // using the protocol structure
-func (c UnixClient) makeRequest(cid models.CmdID, payload interface{}) []byte {
+func (c UnixClient) makeRequest(cid models.CmdID, payload interface{}) ([]byte, error) {
 	conn, err := net.Dial("unix", c.path)
-	maybe(err, c.logger)
+	if err != nil {
+		return nil, &models.Error{Type: models.ErrorTypeUnavailable, Err: err}
+	}
 	defer conn.Close()
-	rxCh := make(chan []byte)
-	raw, _ := json.Marshal(&models.Protocol{Cid: cid, Payload: payload})
-	conn.Write(raw)
-	go c.read(rxCh, conn)
-	return <-rxCh
+	raw, err := json.Marshal(&models.Protocol{Cid: cid, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(raw); err != nil {
+		return nil, &models.Error{Type: models.ErrorTypeUnavailable, Err: err}
+	}
+	var response json.RawMessage
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return nil, fmt.Errorf("malformed response: %w", err)
+		}
+		return nil, &models.Error{Type: models.ErrorTypeUnavailable, Err: err}
+	}
+	return response, nil
 }
 
-// read from socket
-func (c UnixClient) read(rxCh chan []byte, conn net.Conn) {
-	buf := make([]byte, 4096)
-	n, err := conn.Read(buf)
-	conn.Close()
-	maybe(err, c.logger)
-	if n > 0 {
-		rxCh <- buf[0:n]
-	} else {
-		rxCh <- nil
+// request sends a request and decodes the payload of the
+// response into response, nil when there is nothing to
+// decode, a failed request returns the error of the server
+func (c UnixClient) request(cid models.CmdID, payload interface{}, response interface{}) error {
+	message, err := c.makeRequest(cid, payload)
+	if err != nil {
+		return err
+	}
+	decoded := models.Protocol{Payload: response}
+	if err := json.Unmarshal(message, &decoded); err != nil {
+		return fmt.Errorf("malformed response: %w", err)
+	}
+	if decoded.Error != nil {
+		return decoded.Error
+	}
+	if decoded.Cid != cid {
+		return fmt.Errorf(models.ErrWrongMessageType, decoded.Cid, cid)
 	}
+	return nil
 }
 
 // createTask requests the creation of a task
 func (c UnixClient) CreateTask(task *models.Task) (int, error) {
-	cid := models.Cmd_CreateTask
-	message := c.makeRequest(cid, task)
-	response := models.Protocol{Payload: 0}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return -1, fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		taskID, ok := response.Payload.(float64) //float64, for JSON numbers
-		valid(ok, c.logger, response.Payload, message)
-		return int(taskID), nil
+	var taskID int
+	if err := c.request(models.Cmd_CreateTask, task, &taskID); err != nil {
+		return -1, err
 	}
-
+	return taskID, nil
 }
 
 // CreatePomodoro requests the server
 // to append a pomodoro to a task
 func (c UnixClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
 	c.logger.Debug("starting CreatePomodoro request")
-	cid := models.Cmd_CreatePomodoro
 	compose := models.PomodoroWithID{TaskID: taskID, Pomodoro: pomodoro}
-	message := c.makeRequest(cid, &compose)
-	response := models.Protocol{Payload: ""}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		message, ok := response.Payload.(string)
-		valid(ok, c.logger, response.Payload, message)
-		if len(message) != 0 {
-			return errors.New(message)
-		} else {
-			return nil
-		}
+	return c.request(models.Cmd_CreatePomodoro, &compose, nil)
+}
+
+// RestoreTaskByID requests the server
+// to take a task out of the trash
+func (c UnixClient) RestoreTaskByID(taskID int) error {
+	return c.request(models.Cmd_RestoreTask, &taskID, nil)
+}
+
+// GetTrash requests the server to
+// provide the tasks in the trash
+func (c UnixClient) GetTrash() (*models.List, error) {
+	trash := &models.List{}
+	if err := c.request(models.Cmd_GetTrash, nil, trash); err != nil {
+		return nil, err
+	}
+	return trash, nil
+}
+
+// EmptyTrash requests the server to permanently
+// delete the tasks in the trash
+func (c UnixClient) EmptyTrash() error {
+	return c.request(models.Cmd_EmptyTrash, nil, nil)
+}
+
+// GetPomodoros requests the server to provide
+// the pomodoros matching the query
+func (c UnixClient) GetPomodoros(query models.PomodoroQuery) ([]*models.Pomodoro, error) {
+	pomodoros := []*models.Pomodoro{}
+	if err := c.request(models.Cmd_GetPomodoros, &query, &pomodoros); err != nil {
+		return nil, err
+	}
+	return pomodoros, nil
+}
+
+// GetPomodoro requests the server
+// to provide a single pomodoro
+func (c UnixClient) GetPomodoro(pomodoroID int) (*models.Pomodoro, error) {
+	pomodoro := &models.Pomodoro{}
+	if err := c.request(models.Cmd_GetPomodoro, pomodoroID, pomodoro); err != nil {
+		return nil, err
 	}
+	return pomodoro, nil
+}
 
+// UpdatePomodoro requests the server to
+// correct the times or the note of a pomodoro
+func (c UnixClient) UpdatePomodoro(pomodoro models.Pomodoro) error {
+	return c.request(models.Cmd_UpdatePomodoro, &pomodoro, nil)
+}
+
+// DeletePomodoroByID requests the server
+// to delete a single pomodoro
+func (c UnixClient) DeletePomodoroByID(pomodoroID int) error {
+	return c.request(models.Cmd_DeletePomodoro, &pomodoroID, nil)
 }
 
 // DeleteTaskByID requests the server
 // to delete a task
 func (c UnixClient) DeleteTaskByID(taskID int) error {
 	c.logger.Debug("starting DeleteTaskByID request")
-	cid := models.Cmd_DeleteTask
-	message := c.makeRequest(cid, &taskID)
-	response := models.Protocol{Payload: ""}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		message, ok := response.Payload.(string)
-		valid(ok, c.logger, response.Payload, message)
-		if len(message) != 0 {
-			return errors.New(message)
-		} else {
-			return nil
-		}
-	}
+	return c.request(models.Cmd_DeleteTask, &taskID, nil)
 }
 
 // GetServerStatus requests the server
 // to provide the status of running tasks
 func (c UnixClient) GetServerStatus() (*models.Status, error) {
 	c.logger.Debug("received GetServerStatus request")
-	cid := models.Cmd_GetServerStatus
-	message := c.makeRequest(cid, nil)
-	response := models.Protocol{Payload: &models.Status{}}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return nil, fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		status, ok := response.Payload.(*models.Status)
-		valid(ok, c.logger, response.Payload, message)
-		return status, nil
+	status := &models.Status{}
+	if err := c.request(models.Cmd_GetServerStatus, nil, status); err != nil {
+		return nil, err
 	}
+	return status, nil
 }
 
 // GetTaskList requests the server
 // to provide the list all tasks
 func (c UnixClient) GetTaskList() (*models.List, error) {
 	c.logger.Debug("received GetTaskList request")
-	cid := models.Cmd_GetList
-	message := c.makeRequest(cid, nil)
-	response := models.Protocol{Payload: &models.List{}}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return nil, fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		list, ok := response.Payload.(*models.List)
-		valid(ok, c.logger, response.Payload, message)
-		return list, nil
+	list := &models.List{}
+	if err := c.request(models.Cmd_GetList, nil, list); err != nil {
+		return nil, err
 	}
+	return list, nil
 }
 
 // GetTask requests the server
 // to provide all info on specific task
 func (c UnixClient) GetTask(taskID int) (*models.Task, error) {
-	cid := models.Cmd_GetTask
-	message := c.makeRequest(cid, taskID)
-	response := models.Protocol{Payload: &models.Task{}}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return nil, fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		task, ok := response.Payload.(*models.Task)
-		valid(ok, c.logger, response.Payload, message)
-		return task, nil
+	task := &models.Task{}
+	if err := c.request(models.Cmd_GetTask, taskID, task); err != nil {
+		return nil, err
 	}
+	return task, nil
 }
 
 // StartTask starts a pomodoro
-func (c UnixClient) StartTask(taskID int) error {
-	task, err := c.GetTask(taskID)
-	maybe(err, c.logger)
+func (c UnixClient) StartTask(taskID int, options models.RunOptions) error {
+	// the server resets the pomodoros of the task for the run
+	task := &models.Task{}
+	if err := c.request(models.Cmd_StartTask, taskID, task); err != nil {
+		return err
+	}
 	r, err := runner.NewRunner(c, task)
-	maybe(err, c.logger)
+	if err != nil {
+		return err
+	}
 	r.Start()
+	if options.Headless {
+		return r.StartHeadless(os.Stdin, os.Stdout, options.Output)
+	}
 	r.StartUI()
 	return nil
 }
 
 // UpdateStatus sends a status update to the server
 func (c UnixClient) UpdateStatus(status *models.Status) error {
-	cid := models.Cmd_UpdateStatus
-	message := c.makeRequest(cid, status)
-	response := models.Protocol{Payload: ""}
-	json.Unmarshal(message, &response)
-	if response.Cid != cid {
-		return fmt.Errorf(models.ErrWrongMessageType, response.Cid, cid)
-	} else {
-		message, ok := response.Payload.(string)
-		valid(ok, c.logger, response.Payload, message)
-		if len(message) != 0 {
-			return errors.New(message)
-		} else {
-			return nil
-		}
+	return c.request(models.Cmd_UpdateStatus, status, nil)
+}
+
+// SendControl asks the server to relay a
+// control to the running session
+func (c UnixClient) SendControl(control models.Control) error {
+	return c.request(models.Cmd_SendControl, &control, nil)
+}
+
+// GetControl takes the next control relayed
+// by the server, nil when there is none
+func (c UnixClient) GetControl() (*models.Control, error) {
+	var control *models.Control
+	if err := c.request(models.Cmd_GetControl, nil, &control); err != nil {
+		return nil, err
 	}
+	return control, nil
 }
 
-func (c UnixClient) Close() error {
-	return nil
+// CreateGoal requests the creation of a goal
+func (c UnixClient) CreateGoal(goal *models.Goal) (int, error) {
+	var goalID int
+	if err := c.request(models.Cmd_CreateGoal, goal, &goalID); err != nil {
+		return -1, err
+	}
+	return goalID, nil
 }
 
-func (c UnixClient) Init(config *conf.Config) (*UnixClient, error) {
-	c.path = config.Server.Unix.Socket
-	c.logger = zap.S().With("package", "client")
-	return &c, nil
+// GetGoals requests the server
+// to provide the list of goals
+func (c UnixClient) GetGoals() ([]models.Goal, error) {
+	goals := []models.Goal{}
+	if err := c.request(models.Cmd_GetGoals, nil, &goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
 }
 
-func maybe(err error, logger *zap.SugaredLogger) {
-	if err != nil {
-		logger.Fatalf("Error:\n%s\n", err)
-		os.Exit(1)
+// DeleteGoalByID requests the server
+// to delete a goal
+func (c UnixClient) DeleteGoalByID(goalID int) error {
+	return c.request(models.Cmd_DeleteGoal, &goalID, nil)
+}
+
+// CreateProject requests the creation of a project
+func (c UnixClient) CreateProject(project *models.Project) (int, error) {
+	var projectID int
+	if err := c.request(models.Cmd_CreateProject, project, &projectID); err != nil {
+		return -1, err
+	}
+	return projectID, nil
+}
+
+// GetProjects requests the server
+// to provide the list of projects
+func (c UnixClient) GetProjects() (models.Projects, error) {
+	projects := models.Projects{}
+	if err := c.request(models.Cmd_GetProjects, nil, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ArchiveProject requests the server to archive
+// a project and the projects nested under it
+func (c UnixClient) ArchiveProject(projectID int) error {
+	return c.request(models.Cmd_ArchiveProject, &projectID, nil)
+}
+
+// GetTags requests the server
+// to provide the list of tags
+func (c UnixClient) GetTags() (models.Tags, error) {
+	tags := models.Tags{}
+	if err := c.request(models.Cmd_GetTags, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// SetTagColor requests the server
+// to set the color of a tag
+func (c UnixClient) SetTagColor(tag models.Tag) error {
+	return c.request(models.Cmd_SetTagColor, &tag, nil)
+}
+
+// RenameTags requests the server to rename
+// tags or to merge them into one
+func (c UnixClient) RenameTags(rename models.TagRename) error {
+	return c.request(models.Cmd_RenameTags, &rename, nil)
+}
+
+// CreateTemplate requests the creation of a task template
+func (c UnixClient) CreateTemplate(template *models.Template) (int, error) {
+	var templateID int
+	if err := c.request(models.Cmd_CreateTemplate, template, &templateID); err != nil {
+		return -1, err
 	}
+	return templateID, nil
 }
 
-func valid(ok bool, logger *zap.SugaredLogger, expected interface{}, received interface{}) {
-	if !ok {
-		logger.Fatalf(models.ErrWrongDataType, expected, received)
+// GetTemplates requests the server
+// to provide the list of templates
+func (c UnixClient) GetTemplates() (models.Templates, error) {
+	templates := models.Templates{}
+	if err := c.request(models.Cmd_GetTemplates, nil, &templates); err != nil {
+		return nil, err
 	}
+	return templates, nil
+}
+
+// DeleteTemplateByID requests the server
+// to delete a template
+func (c UnixClient) DeleteTemplateByID(templateID int) error {
+	return c.request(models.Cmd_DeleteTemplate, &templateID, nil)
+}
+
+// AddNote requests a note to be added
+// to a task or one of its pomodoros
+func (c UnixClient) AddNote(taskID int, note models.Note) error {
+	return c.request(models.Cmd_AddNote, &models.NoteWithID{TaskID: taskID, Note: note}, nil)
+}
+
+// GetQueue returns no request, the server
+// listens on the same host as the client
+func (c UnixClient) GetQueue() ([]models.QueuedRequest, error) {
+	return []models.QueuedRequest{}, nil
+}
+
+// FlushQueue has nothing to replay
+func (c UnixClient) FlushQueue() (int, error) {
+	return 0, nil
+}
+
+// Sync requests the server to exchange the tasks
+// and pomodoros with the pomo server at remote
+func (c UnixClient) Sync(remote string) (*models.SyncReport, error) {
+	report := &models.SyncReport{}
+	if err := c.request(models.Cmd_Sync, &models.SyncRemote{Remote: remote}, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (c UnixClient) Close() error {
+	return nil
+}
+
+func (c UnixClient) Init(config *conf.Config) (*UnixClient, error) {
+	c.path = config.Server.Unix.Socket
+	c.logger = zap.S().With("package", "client")
+	return &c, nil
 }
@@ -0,0 +1,50 @@
+package unix
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestLargeResponses(t *testing.T) {
+	// a socket path must be short, t.TempDir may be too long
+	dir, err := os.MkdirTemp("", "pomo")
+	assert.NilError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := path.Join(dir, "pomo.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NilError(t, err)
+	defer listener.Close()
+
+	// far more than a single read of the socket
+	long := strings.Repeat("a long message ", 1000)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		request := models.Protocol{}
+		if err := json.NewDecoder(conn).Decode(&request); err != nil {
+			return
+		}
+		list := models.List{{ID: 1, Message: long}, {ID: 2, Message: long}}
+		_ = json.NewEncoder(conn).Encode(&models.Protocol{Cid: request.Cid, Payload: list})
+	}()
+
+	c := UnixClient{path: socket, logger: zap.NewNop().Sugar()}
+	list, err := c.GetTaskList()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(*list, 2))
+	for _, task := range *list {
+		assert.Check(t, is.Equal(task.Message, long))
+	}
+}
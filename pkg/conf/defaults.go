@@ -54,6 +54,7 @@ func LoadDefaultConfig() *Config {
 	viper.SetDefault("server.unix.socket", defaultConfigPath()+"/pomo.sock")
 	viper.SetDefault("server.datetimeformat", "2006-01-02 15:04")
 	viper.SetDefault("server.log_requests", true)
+	viper.SetDefault("server.metrics", true)
 
 	viper.SetDefault("database.username", "postgres")
 	viper.SetDefault("database.password", "password")
@@ -70,6 +71,48 @@ func LoadDefaultConfig() *Config {
 	viper.SetDefault("database.log_queries", true)
 	viper.SetDefault("database.path", defaultConfigPath()+"/pomo.db")
 
+	viper.SetDefault("webhooks.endpoints", []interface{}{})
+	viper.SetDefault("webhooks.attempts", 5)
+	viper.SetDefault("webhooks.backoff", "30s")
+	viper.SetDefault("webhooks.interval", "10s")
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.retention", "168h")
+
+	viper.SetDefault("notifier.backends", []string{"desktop"})
+	viper.SetDefault("notifier.icon", defaultConfigPath()+"/icon.png")
+	viper.SetDefault("notifier.sound", "")
+	viper.SetDefault("notifier.player", "paplay")
+	viper.SetDefault("notifier.command", "")
+	viper.SetDefault("notifier.break.title", DefaultBreakTitle)
+	viper.SetDefault("notifier.break.body", DefaultBreakBody)
+	viper.SetDefault("notifier.complete.title", DefaultCompleteTitle)
+	viper.SetDefault("notifier.complete.body", DefaultCompleteBody)
+
+	viper.SetDefault("runner.log", defaultConfigPath()+"/session.log")
+	viper.SetDefault("runner.pause.timeout", "1h")
+	viper.SetDefault("runner.pause.action", "remind")
+	viper.SetDefault("runner.pause.interval", "15m")
+	viper.SetDefault("runner.break.timeout", "30m")
+	viper.SetDefault("runner.break.action", "remind")
+	viper.SetDefault("runner.break.interval", "10m")
+	viper.SetDefault("runner.autostart.break", false)
+	viper.SetDefault("runner.autostart.pomodoro", false)
+	viper.SetDefault("runner.autostart.duration", "5m")
+	viper.SetDefault("runner.autostart.warning", "30s")
+
+	viper.SetDefault("recurring.interval", "1m")
+
+	viper.SetDefault("trash.retention", "720h")
+	viper.SetDefault("trash.interval", "1h")
+
+	viper.SetDefault("backup.dir", defaultConfigPath()+"/backups")
+	viper.SetDefault("backup.interval", "24h")
+	viper.SetDefault("backup.keep", 7)
+
+	viper.SetDefault("client.journal", defaultConfigPath()+"/journal.json")
+
+	viper.SetDefault("colors", map[string]string{})
+
 	var config Config
 	viper.Unmarshal(&config)
 	return &config
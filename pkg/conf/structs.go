@@ -1,11 +1,23 @@
 package conf
 
+import "github.com/joaorufino/pomo/pkg/core/models"
+
 // Config represents the application's configuration
 type Config struct {
-	Logger   LoggerConfig
-	Pidfile  string
-	Server   ServerConfig
-	Database DatabaseConfig
+	Logger    LoggerConfig
+	Pidfile   string
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Webhooks  WebhooksConfig
+	Notifier  NotifierConfig
+	Runner    RunnerConfig
+	Recurring RecurringConfig
+	Trash     TrashConfig
+	Backup    BackupConfig
+	Client    ClientConfig
+	// Colors of the tags: one of models.Colors,
+	// a 256 color number or a #rrggbb true color
+	Colors map[string]string
 }
 
 // LoggerConfig represents the logger's configuration
@@ -28,6 +40,7 @@ type ServerConfig struct {
 	UnixSocket     string
 	DatetimeFormat string
 	LogRequests    bool
+	Metrics        bool
 }
 
 // DatabaseConfig represents the database's configuration
@@ -47,3 +60,118 @@ type DatabaseConfig struct {
 	LogQueries          bool
 	Path                string
 }
+
+// WebhooksConfig represents the outgoing webhooks configuration
+type WebhooksConfig struct {
+	Endpoints []models.WebhookEndpoint
+	// Maximum number of delivery attempts per event
+	Attempts int
+	// Delay before the first retry, doubled on every attempt
+	Backoff string
+	// How often the delivery queue is polled
+	Interval string
+	// Timeout of a single delivery request
+	Timeout string
+	// Time a failed delivery is kept before it is purged,
+	// empty keeps it until it is deleted from the database
+	Retention string
+}
+
+// NotifierConfig represents the notifications configuration
+type NotifierConfig struct {
+	// Enabled backends: desktop, bell, sound and command
+	Backends []string
+	// Icon shown by desktop notifications
+	Icon string
+	// Audio file and the command used to play it
+	Sound  string
+	Player string
+	// Shell command run by the command backend
+	Command string
+	// Templates of the notifications
+	Break    MessageConfig
+	Complete MessageConfig
+}
+
+// MessageConfig holds the title and body templates of a notification
+type MessageConfig struct {
+	Title string
+	Body  string
+}
+
+// Templates of the notifications when none are configured
+const (
+	DefaultBreakTitle    = "Pomo - {{.Message}}"
+	DefaultBreakBody     = "[{{.Count}}/{{.NPomodoros}}] It is time to take a break!"
+	DefaultCompleteTitle = "Pomo - {{.Message}}"
+	DefaultCompleteBody  = "Pomo session has been completed!"
+)
+
+// RunnerConfig represents the configuration of the sessions
+type RunnerConfig struct {
+	// File receiving the output of detached sessions
+	Log string
+	// What happens to a pomodoro left paused
+	// and to a break that is not ended
+	Pause IdleConfig
+	Break IdleConfig
+	// Starting breaks and pomodoros without the user
+	AutoStart AutoStartConfig
+}
+
+// AutoStartConfig represents how breaks and pomodoros start on their own,
+// tasks can override it
+type AutoStartConfig struct {
+	// Time the breaks from the end of each pomodoro
+	Break bool
+	// Start the next pomodoro once the break elapses,
+	// the breaks are then timed even without Break
+	Pomodoro bool
+	// Length of the timed breaks
+	Duration string
+	// Time before a pomodoro starts on its own the user is notified
+	Warning string
+}
+
+// RecurringConfig represents how the server creates the recurring tasks
+type RecurringConfig struct {
+	// How often the templates are checked for due tasks
+	Interval string
+}
+
+// TrashConfig represents how long the deleted tasks are kept
+type TrashConfig struct {
+	// Time a deleted task is kept before it is purged,
+	// empty keeps it until the trash is emptied
+	Retention string
+	// How often the trash is checked for tasks to purge
+	Interval string
+}
+
+// BackupConfig represents how the server backs up the database
+type BackupConfig struct {
+	// Directory the backups are written to
+	Dir string
+	// Time between two automatic backups, empty disables them
+	Interval string
+	// Number of backups kept, the oldest are removed first,
+	// zero or less keeps all of them
+	Keep int
+}
+
+// ClientConfig represents the client's configuration
+type ClientConfig struct {
+	// File queuing the writes while the rest server is
+	// unreachable, empty fails them instead
+	Journal string
+}
+
+// IdleConfig represents what happens to a session waiting on the user
+type IdleConfig struct {
+	// Time after which the session is idle, empty waits forever
+	Timeout string
+	// remind notifies the user every Interval,
+	// abandon ends the session keeping its progress
+	Action   string
+	Interval string
+}
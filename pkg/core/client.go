@@ -9,10 +9,41 @@ type Client interface {
 	CreateTask(task *models.Task) (int, error)
 	Close() error
 	DeleteTaskByID(taskID int) error
+	RestoreTaskByID(taskID int) error
+	GetTrash() (*models.List, error)
+	EmptyTrash() error
 	GetServerStatus() (*models.Status, error)
 	GetTaskList() (*models.List, error)
-	StartTask(taskID int) error
+	// GetTask returns the task and its pomodoros, an error of
+	// type models.ErrorTypeNotFound when it is missing or trashed
+	GetTask(taskID int) (*models.Task, error)
+	StartTask(taskID int, options models.RunOptions) error
 	UpdateStatus(status *models.Status) error
 	Config() *koanf.Koanf
 	CreatePomodoro(taskID int, pomodoro models.Pomodoro) error
+	GetPomodoros(query models.PomodoroQuery) ([]*models.Pomodoro, error)
+	GetPomodoro(pomodoroID int) (*models.Pomodoro, error)
+	UpdatePomodoro(pomodoro models.Pomodoro) error
+	DeletePomodoroByID(pomodoroID int) error
+	SendControl(control models.Control) error
+	GetControl() (*models.Control, error)
+	CreateGoal(goal *models.Goal) (int, error)
+	GetGoals() ([]models.Goal, error)
+	DeleteGoalByID(goalID int) error
+	CreateProject(project *models.Project) (int, error)
+	GetProjects() (models.Projects, error)
+	ArchiveProject(projectID int) error
+	GetTags() (models.Tags, error)
+	SetTagColor(tag models.Tag) error
+	RenameTags(rename models.TagRename) error
+	CreateTemplate(template *models.Template) (int, error)
+	GetTemplates() (models.Templates, error)
+	DeleteTemplateByID(templateID int) error
+	AddNote(taskID int, note models.Note) error
+	// GetQueue returns the writes queued while the server was unreachable
+	GetQueue() ([]models.QueuedRequest, error)
+	// FlushQueue replays the queued writes and returns how many were sent
+	FlushQueue() (int, error)
+	// Sync exchanges the tasks, pomodoros and notes with the server at remote
+	Sync(remote string) (*models.SyncReport, error)
 }
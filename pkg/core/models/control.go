@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ControlAction is an action requested on the running session
+type ControlAction string
+
+const (
+	// ControlSkip ends the current pomodoro or break early
+	ControlSkip ControlAction = "skip"
+	// ControlExtend adds Duration to the current pomodoro
+	ControlExtend ControlAction = "extend"
+	// ControlRestart starts the current pomodoro over
+	ControlRestart ControlAction = "restart"
+	// ControlStop ends the session keeping the progress made
+	ControlStop ControlAction = "stop"
+	// ControlPause pauses or resumes the current pomodoro
+	ControlPause ControlAction = "pause"
+	// ControlNext starts the next pomodoro after a break
+	ControlNext ControlAction = "next"
+)
+
+// Control is relayed through the server to
+// the client running the session
+type Control struct {
+	Action   ControlAction `json:"action"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Validate checks the action is known and
+// extensions have a positive duration
+func (c Control) Validate() error {
+	switch c.Action {
+	case ControlSkip, ControlRestart, ControlStop, ControlPause, ControlNext:
+		return nil
+	case ControlExtend:
+		if c.Duration <= 0 {
+			return fmt.Errorf("extend requires a positive duration, got %s", c.Duration)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown control action %q", c.Action)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -20,22 +21,105 @@ const (
 	ErrorTypeDuplicate
 	ErrorTypeInvalid
 	ErrorTypeQuery
+	// ErrorTypeNotFound is a missing task, pomodoro, etc.
+	ErrorTypeNotFound
+	// ErrorTypeUnavailable is a server that could not be reached
+	ErrorTypeUnavailable
+	// ErrorTypeInternal is any other failure
+	ErrorTypeInternal
+)
 
+const (
 	ErrorOpSave ErrorOp = iota
 	ErrorOpGet
 	ErrorOpDelete
 	ErrorOpFind
 )
 
+var errorTypeNames = map[ErrorType]string{
+	ErrorTypeNone:        "none",
+	ErrorTypeIncomplete:  "incomplete",
+	ErrorTypeForeignKey:  "foreign_key",
+	ErrorTypeDuplicate:   "duplicate",
+	ErrorTypeInvalid:     "invalid",
+	ErrorTypeQuery:       "query",
+	ErrorTypeNotFound:    "not_found",
+	ErrorTypeUnavailable: "unavailable",
+	ErrorTypeInternal:    "internal",
+}
+
+func (t ErrorType) String() string {
+	if name, ok := errorTypeNames[t]; ok {
+		return name
+	}
+	return errorTypeNames[ErrorTypeInternal]
+}
+
+// ParseErrorType returns the type named name,
+// ErrorTypeInternal when it is unknown
+func ParseErrorType(name string) ErrorType {
+	for t, typeName := range errorTypeNames {
+		if typeName == name {
+			return t
+		}
+	}
+	return ErrorTypeInternal
+}
+
 type Error struct {
 	Type ErrorType
 	Err  error
 }
 
+// NewError returns an error of the type with a formatted message
+func NewError(t ErrorType, format string, args ...interface{}) *Error {
+	return &Error{Type: t, Err: fmt.Errorf(format, args...)}
+}
+
+// TypeOf returns the type of err, ErrNotFound is
+// ErrorTypeNotFound and an untyped error is ErrorTypeInternal
+func TypeOf(err error) ErrorType {
+	var typed *Error
+	switch {
+	case err == nil:
+		return ErrorTypeNone
+	case errors.As(err, &typed):
+		return typed.Type
+	case errors.Is(err, ErrNotFound):
+		return ErrorTypeNotFound
+	}
+	return ErrorTypeInternal
+}
+
 func (e *Error) Error() string { return e.Err.Error() }
 
 func (e *Error) Unwrap() error { return e.Err }
 
+// Is makes the errors of ErrorTypeNotFound match ErrNotFound
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.Type == ErrorTypeNotFound
+}
+
+// errorJSON is how an Error is sent to the clients
+type errorJSON struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{Type: e.Type.String(), Message: e.Error()})
+}
+
+func (e *Error) UnmarshalJSON(raw []byte) error {
+	decoded := errorJSON{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return err
+	}
+	e.Type = ParseErrorType(decoded.Type)
+	e.Err = errors.New(decoded.Message)
+	return nil
+}
+
 func (e *Error) ErrorForOp(op ErrorOp) error {
 	switch e.Type {
 	case ErrorTypeNone:
@@ -56,3 +140,16 @@ type Results struct {
 	Count   int64       `json:"count"`
 	Results interface{} `json:"results"`
 }
+
+// DatabaseCheck is the outcome of checking the database
+type DatabaseCheck struct {
+	// Problems reported by the integrity check, empty when it passed
+	Integrity []string `json:"integrity"`
+	// Pomodoros whose task does not exist anymore
+	OrphanedPomodoros []*Pomodoro `json:"orphaned_pomodoros"`
+}
+
+// OK reports whether the check found no problem
+func (c DatabaseCheck) OK() bool {
+	return len(c.Integrity) == 0 && len(c.OrphanedPomodoros) == 0
+}
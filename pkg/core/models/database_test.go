@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestErrorType(t *testing.T) {
+	assert.Equal(t, TypeOf(nil), ErrorTypeNone)
+	assert.Equal(t, TypeOf(ErrNotFound), ErrorTypeNotFound)
+	assert.Equal(t, TypeOf(fmt.Errorf("task 3: %w", ErrNotFound)), ErrorTypeNotFound)
+	assert.Equal(t, TypeOf(errors.New("disk full")), ErrorTypeInternal)
+	assert.Equal(t, TypeOf(fmt.Errorf("save: %w", NewError(ErrorTypeDuplicate, "tag %q", "docs"))), ErrorTypeDuplicate)
+
+	assert.Assert(t, errors.Is(NewError(ErrorTypeNotFound, "task 3 does not exist"), ErrNotFound))
+	assert.Assert(t, !errors.Is(NewError(ErrorTypeInvalid, "no message"), ErrNotFound))
+
+	for _, errorType := range []ErrorType{ErrorTypeIncomplete, ErrorTypeUnavailable, ErrorTypeInternal} {
+		assert.Equal(t, ParseErrorType(errorType.String()), errorType)
+	}
+	assert.Equal(t, ParseErrorType("unknown"), ErrorTypeInternal)
+}
+
+// TestErrorJSON sends an error the way the servers answer
+func TestErrorJSON(t *testing.T) {
+	raw, err := json.Marshal(NewError(ErrorTypeNotFound, "pomodoro %d does not exist", 4))
+	assert.NilError(t, err)
+	assert.Equal(t, string(raw), `{"type":"not_found","message":"pomodoro 4 does not exist"}`)
+
+	decoded := &Error{}
+	assert.NilError(t, json.Unmarshal(raw, decoded))
+	assert.Equal(t, decoded.Type, ErrorTypeNotFound)
+	assert.Error(t, decoded, "pomodoro 4 does not exist")
+
+	// a successful answer carries no error
+	raw, err = json.Marshal(Protocol{Cid: Cmd_GetTask, Payload: ""})
+	assert.NilError(t, err)
+	assert.Equal(t, string(raw), `{"Cid":5,"Payload":""}`)
+}
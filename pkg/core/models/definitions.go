@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"time"
@@ -28,6 +30,11 @@ func (s State) String() string {
 	return ""
 }
 
+// Active reports whether a session is in progress
+func (s State) Active() bool {
+	return s == RUNNING || s == BREAKING || s == PAUSED
+}
+
 const (
 	RUNNING State = iota + 1
 	BREAKING
@@ -68,6 +75,31 @@ type Task struct {
 	NPomodoros int `json:"n_pomodoros"`
 	// Duration of each pomodoro
 	Duration time.Duration `json:"duration"`
+	// Duration of the timed breaks, zero uses the configured one
+	BreakDuration time.Duration `json:"break_duration,omitempty"`
+	// Whether breaks and the pomodoros following them
+	// start on their own, nil uses the configuration
+	AutoStartBreak    *bool `json:"auto_start_break,omitempty"`
+	AutoStartPomodoro *bool `json:"auto_start_pomodoro,omitempty"`
+	// Project the task belongs to, zero for none
+	ProjectID int `json:"project_id,omitempty"`
+	// Journal of the task, oldest first
+	Notes []Note `json:"notes,omitempty"`
+	// When the task was moved to the trash, nil when it was not
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Identify the task and its last change across synced servers
+	UUID      string     `json:"uuid,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// HasTag reports whether the task is tagged with tag
+func (t Task) HasTag(tag string) bool {
+	for _, taskTag := range t.Tags {
+		if taskTag == tag {
+			return true
+		}
+	}
+	return false
 }
 
 type ListResults struct {
@@ -100,8 +132,15 @@ func After(start time.Time, tasks []Task) []Task {
 // Pomodoro is a unit of time to spend working
 // on a single task.
 type Pomodoro struct {
-	Start time.Time `json:"start"`
-	End   time.Time `json:"end"`
+	ID     int       `json:"id,omitempty"`
+	TaskID int       `json:"task_id,omitempty"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	// What was accomplished in the pomodoro
+	Note string `json:"note,omitempty"`
+	// Identify the pomodoro and its last change across synced servers
+	UUID      string     `json:"uuid,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 // PomodoroWithID is a unit for requesting
@@ -116,9 +155,21 @@ func (p Pomodoro) Duration() time.Duration {
 	return (p.End.Sub(p.Start))
 }
 
+// Validate checks the pomodoro ends after it starts
+func (p Pomodoro) Validate() error {
+	if p.Start.IsZero() || p.End.IsZero() {
+		return errors.New("pomodoro start and end are required")
+	}
+	if !p.End.After(p.Start) {
+		return fmt.Errorf("pomodoro ends at %s, before it starts at %s", p.End.Format(time.RFC3339), p.Start.Format(time.RFC3339))
+	}
+	return nil
+}
+
 // Status is used to communicate the state
 // of a running Pomodoro session
 type Status struct {
+	TaskID     int           `json:"task_id,omitempty"`
 	State      State         `json:"state"`
 	Remaining  time.Duration `json:"remaining"`
 	Count      int           `json:"count"`
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPomodoroValidate(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	assert.NilError(t, Pomodoro{Start: start, End: start.Add(25 * time.Minute)}.Validate())
+	assert.ErrorContains(t, Pomodoro{Start: start}.Validate(), "required")
+	assert.ErrorContains(t, Pomodoro{Start: start, End: start}.Validate(), "before it starts")
+	assert.ErrorContains(t, Pomodoro{Start: start, End: start.Add(-time.Minute)}.Validate(), "before it starts")
+}
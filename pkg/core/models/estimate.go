@@ -0,0 +1,161 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// EstimateMinTasks is the number of tasks of a tag
+	// needed before its estimates are judged
+	EstimateMinTasks = 3
+	// EstimateUnderRatio is the ratio of actual to estimated
+	// above which a tag is flagged as underestimated
+	EstimateUnderRatio = 1.25
+)
+
+// Estimate compares the pomodoros planned for tasks with the
+// time spent on them, counted in pomodoros of the task duration
+type Estimate struct {
+	Tasks     int     `json:"tasks"`
+	Estimated float64 `json:"estimated"`
+	Actual    float64 `json:"actual"`
+}
+
+// TaskEstimate returns the estimate of a task, false until
+// the task is finished, ie all its pomodoros were worked on
+func TaskEstimate(task Task) (Estimate, bool) {
+	if task.NPomodoros <= 0 || len(task.Pomodoros) < task.NPomodoros || task.Duration <= 0 {
+		return Estimate{}, false
+	}
+	var spent time.Duration
+	for _, pomodoro := range task.Pomodoros {
+		spent += pomodoro.Duration()
+	}
+	return Estimate{
+		Tasks:     1,
+		Estimated: float64(task.NPomodoros),
+		Actual:    float64(spent) / float64(task.Duration),
+	}, true
+}
+
+// Ratio returns the actual over the estimated pomodoros,
+// above 1 the tasks were underestimated
+func (e Estimate) Ratio() float64 {
+	if e.Estimated == 0 {
+		return 0
+	}
+	return e.Actual / e.Estimated
+}
+
+func (e *Estimate) add(other Estimate) {
+	e.Tasks += other.Tasks
+	e.Estimated += other.Estimated
+	e.Actual += other.Actual
+}
+
+// TaskEstimateResult is the estimate of a single task
+type TaskEstimateResult struct {
+	ID      int      `json:"id"`
+	Message string   `json:"message"`
+	Tags    []string `json:"tags,omitempty"`
+	Estimate
+}
+
+// WeekEstimate is the estimate of the tasks
+// started in the week beginning at Start
+type WeekEstimate struct {
+	Start time.Time `json:"start"`
+	Estimate
+}
+
+// EstimateReport compares the estimates of the
+// tasks with their actuals, per task, tag and week
+type EstimateReport struct {
+	Total Estimate             `json:"total"`
+	Tasks []TaskEstimateResult `json:"tasks"`
+	Tags  map[string]Estimate  `json:"tags"`
+	// Oldest first, the last one contains now
+	Weeks []WeekEstimate `json:"weeks"`
+}
+
+// NewEstimateReport returns the report of the finished
+// tasks, over the last weeks up to now
+func NewEstimateReport(tasks List, now time.Time, weeks int) EstimateReport {
+	report := EstimateReport{
+		Tasks: []TaskEstimateResult{},
+		Tags:  map[string]Estimate{},
+		Weeks: make([]WeekEstimate, weeks),
+	}
+	week := Goal{Period: GoalWeekly}
+	start := week.Start(now)
+	for i := weeks - 1; i >= 0; i-- {
+		report.Weeks[i].Start = start
+		start = start.AddDate(0, 0, -7)
+	}
+	for _, task := range tasks {
+		estimate, ok := TaskEstimate(task)
+		if !ok {
+			continue
+		}
+		report.Total.add(estimate)
+		report.Tasks = append(report.Tasks, TaskEstimateResult{ID: task.ID, Message: task.Message, Tags: task.Tags, Estimate: estimate})
+		for _, tag := range task.Tags {
+			tagged := report.Tags[tag]
+			tagged.add(estimate)
+			report.Tags[tag] = tagged
+		}
+		// tasks count in the week they were started
+		started := week.Start(task.Pomodoros[0].Start.In(now.Location()))
+		for i := range report.Weeks {
+			if report.Weeks[i].Start.Equal(started) {
+				report.Weeks[i].add(estimate)
+			}
+		}
+	}
+	return report
+}
+
+// Underestimated returns the tags whose tasks chronically
+// took more than EstimateUnderRatio their estimate
+func (r EstimateReport) Underestimated() []string {
+	tags := []string{}
+	for tag, estimate := range r.Tags {
+		if estimate.Tasks >= EstimateMinTasks && estimate.Ratio() > EstimateUnderRatio {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Suggest returns the pomodoros the history of the tasks
+// sharing a tag suggests for a task estimated at pomodoros,
+// false when there are too few such tasks or the estimate
+// is in line, a task with several of the tags counts once
+func (r EstimateReport) Suggest(tags []string, pomodoros int) (int, Estimate, bool) {
+	history := Estimate{}
+	for _, task := range r.Tasks {
+		if sharesTag(task.Tags, tags) {
+			history.add(task.Estimate)
+		}
+	}
+	if history.Tasks < EstimateMinTasks {
+		return pomodoros, history, false
+	}
+	suggested := int(math.Max(1, math.Round(float64(pomodoros)*history.Ratio())))
+	return suggested, history, suggested != pomodoros
+}
+
+// sharesTag is true when one of tags is in taskTags
+func sharesTag(taskTags, tags []string) bool {
+	for _, tag := range tags {
+		for _, taskTag := range taskTags {
+			if tag == taskTag {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestEstimateReport(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2021, 1, 13, 15, 0, 0, 0, time.Local)
+	task := func(id, estimated int, spent []time.Duration, daysAgo int, tags ...string) Task {
+		task := Task{ID: id, NPomodoros: estimated, Duration: 25 * time.Minute, Tags: tags}
+		start := now.AddDate(0, 0, -daysAgo)
+		for _, duration := range spent {
+			task.Pomodoros = append(task.Pomodoros, &Pomodoro{Start: start, End: start.Add(duration)})
+		}
+		return task
+	}
+	long := []time.Duration{50 * time.Minute, 50 * time.Minute}
+	tasks := List{
+		task(1, 2, long, 0, "docs"),
+		task(2, 1, long[:1], 1, "docs"),
+		task(3, 2, long, 9, "docs", "code"),
+		task(4, 1, []time.Duration{25 * time.Minute}, 2, "code"),
+		// not finished yet
+		task(5, 4, nil, 0, "docs"),
+		task(6, 4, []time.Duration{25 * time.Minute}, 0, "docs"),
+	}
+	report := NewEstimateReport(tasks, now, 2)
+
+	assert.Check(t, is.Len(report.Tasks, 4))
+	assert.Check(t, is.Equal(report.Tasks[0].Ratio(), 2.0))
+	assert.Check(t, is.DeepEqual(report.Tags["docs"], Estimate{Tasks: 3, Estimated: 5, Actual: 10}))
+	assert.Check(t, is.DeepEqual(report.Underestimated(), []string{"docs"}), "code has too few tasks")
+
+	assert.Assert(t, is.Len(report.Weeks, 2))
+	assert.Check(t, is.Equal(report.Weeks[1].Start, time.Date(2021, 1, 11, 0, 0, 0, 0, time.Local)))
+	assert.Check(t, is.Equal(report.Weeks[1].Tasks, 3))
+	assert.Check(t, is.Equal(report.Weeks[0].Tasks, 1))
+
+	suggested, history, ok := report.Suggest([]string{"docs"}, 3)
+	assert.Check(t, ok)
+	assert.Check(t, is.Equal(suggested, 6))
+	assert.Check(t, is.Equal(history.Ratio(), 2.0))
+	// task 3 is tagged with both and counts once
+	_, history, ok = report.Suggest([]string{"docs", "code"}, 3)
+	assert.Check(t, ok)
+	assert.Check(t, is.DeepEqual(history, Estimate{Tasks: 4, Estimated: 6, Actual: 11}))
+	_, _, ok = report.Suggest([]string{"code"}, 3)
+	assert.Check(t, !ok, "too few tasks")
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// GoalPeriod is the span of time a goal has to be met in
+type GoalPeriod string
+
+// GoalUnit is what a goal counts
+type GoalUnit string
+
+const (
+	GoalDaily  GoalPeriod = "day"
+	GoalWeekly GoalPeriod = "week"
+
+	// GoalPomodoros counts the pomodoros worked
+	GoalPomodoros GoalUnit = "pomodoros"
+	// GoalHours counts the time spent in pomodoros
+	GoalHours GoalUnit = "hours"
+)
+
+// Goal is a target of pomodoros or focused
+// hours to reach every day or week
+type Goal struct {
+	ID     int        `json:"id"`
+	Period GoalPeriod `json:"period"`
+	Unit   GoalUnit   `json:"unit"`
+	Target float64    `json:"target"`
+	// Only counts the pomodoros of tasks with this tag
+	Tag string `json:"tag,omitempty"`
+	// Set by the store when the goal is saved,
+	// missing for the goals of older databases
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Validate checks the period and unit are
+// known and the target is positive
+func (g Goal) Validate() error {
+	switch g.Period {
+	case GoalDaily, GoalWeekly:
+	default:
+		return fmt.Errorf("unknown goal period %q, expected %s or %s", g.Period, GoalDaily, GoalWeekly)
+	}
+	switch g.Unit {
+	case GoalPomodoros, GoalHours:
+	default:
+		return fmt.Errorf("unknown goal unit %q, expected %s or %s", g.Unit, GoalPomodoros, GoalHours)
+	}
+	if g.Target <= 0 {
+		return fmt.Errorf("goal target must be positive, got %g", g.Target)
+	}
+	return nil
+}
+
+// Start returns the beginning of the period containing t,
+// weeks start on Monday
+func (g Goal) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	if g.Period == GoalWeekly {
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	}
+	return start
+}
+
+// next returns the beginning of the period after the one starting at start
+func (g Goal) next(start time.Time) time.Time {
+	if g.Period == GoalWeekly {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Progress returns how much of the goal the tasks
+// reached in the period containing t
+func (g Goal) Progress(tasks List, t time.Time) GoalProgress {
+	start := g.Start(t)
+	progress := GoalProgress{Goal: g, Start: start}
+	end := g.next(start)
+	for _, task := range tasks {
+		if g.Tag != "" && !task.HasTag(g.Tag) {
+			continue
+		}
+		for _, pomodoro := range task.Pomodoros {
+			if pomodoro.Start.Before(start) || !pomodoro.Start.Before(end) {
+				continue
+			}
+			if g.Unit == GoalHours {
+				progress.Done += pomodoro.Duration().Hours()
+			} else {
+				progress.Done++
+			}
+		}
+	}
+	return progress
+}
+
+// History returns the progress of the last n periods up to the
+// one containing t, oldest first, the periods before the goal was
+// created are left out and the one containing t is in progress
+func (g Goal) History(tasks List, t time.Time, n int) []GoalProgress {
+	history := []GoalProgress{}
+	start := g.Start(t)
+	for i := 0; i < n; i++ {
+		if i > 0 && g.CreatedAt != nil && start.Before(g.Start(*g.CreatedAt)) {
+			break
+		}
+		history = append([]GoalProgress{g.Progress(tasks, start)}, history...)
+		if g.Period == GoalWeekly {
+			start = start.AddDate(0, 0, -7)
+		} else {
+			start = start.AddDate(0, 0, -1)
+		}
+	}
+	if len(history) > 0 {
+		history[len(history)-1].InProgress = true
+	}
+	return history
+}
+
+// GoalResults is the list of goals returned by the server
+type GoalResults struct {
+	Count   int64  `json:"count"`
+	Results []Goal `json:"results"`
+}
+
+// GoalProgress is how much of a goal was
+// reached in the period starting at Start
+type GoalProgress struct {
+	Goal  Goal
+	Start time.Time
+	Done  float64
+	// The period has not ended yet
+	InProgress bool
+}
+
+// Hit reports whether the goal was reached
+func (p GoalProgress) Hit() bool {
+	return p.Done >= p.Goal.Target
+}
+
+// Missed reports whether the period ended without reaching the goal
+func (p GoalProgress) Missed() bool {
+	return !p.InProgress && !p.Hit()
+}
+
+// String summarises the progress, eg: "day: 3/8 pomodoros"
+func (p GoalProgress) String() string {
+	scope := string(p.Goal.Period)
+	if p.Goal.Tag != "" {
+		scope += " #" + p.Goal.Tag
+	}
+	if p.Goal.Unit == GoalHours {
+		return fmt.Sprintf("%s: %.1f/%gh", scope, p.Done, p.Goal.Target)
+	}
+	return fmt.Sprintf("%s: %g/%g pomodoros", scope, p.Done, p.Goal.Target)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestGoalProgress(t *testing.T) {
+	// Saturday
+	now := time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC)
+	pomodoro := func(days int) *Pomodoro {
+		start := now.AddDate(0, 0, -days)
+		return &Pomodoro{Start: start, End: start.Add(30 * time.Minute)}
+	}
+	tasks := List{
+		{Tags: []string{"docs"}, Pomodoros: []*Pomodoro{pomodoro(0), pomodoro(0), pomodoro(1)}},
+		{Pomodoros: []*Pomodoro{pomodoro(0), pomodoro(5), pomodoro(6)}},
+	}
+
+	daily := Goal{Period: GoalDaily, Unit: GoalPomodoros, Target: 3}
+	progress := daily.Progress(tasks, now)
+	assert.Check(t, is.Equal(progress.Done, 3.0))
+	assert.Check(t, progress.Hit())
+	assert.Check(t, is.Equal(progress.String(), "day: 3/3 pomodoros"))
+
+	tagged := Goal{Period: GoalDaily, Unit: GoalHours, Target: 2, Tag: "docs"}
+	progress = tagged.Progress(tasks, now)
+	assert.Check(t, is.Equal(progress.Done, 1.0))
+	assert.Check(t, !progress.Hit())
+	assert.Check(t, is.Equal(progress.String(), "day #docs: 1.0/2h"))
+
+	// the week started on Monday the 11th, the 10th is left out
+	weekly := Goal{Period: GoalWeekly, Unit: GoalPomodoros, Target: 10}
+	assert.Check(t, is.Equal(weekly.Start(now), time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC)))
+	assert.Check(t, is.Equal(weekly.Progress(tasks, now).Done, 5.0))
+}
+
+func TestGoalHistory(t *testing.T) {
+	now := time.Date(2021, 1, 16, 15, 0, 0, 0, time.UTC)
+	tasks := List{{Pomodoros: []*Pomodoro{
+		{Start: now.AddDate(0, 0, -2)},
+		{Start: now},
+		{Start: now},
+	}}}
+	goal := Goal{Period: GoalDaily, Unit: GoalPomodoros, Target: 1}
+	history := goal.History(tasks, now, 3)
+	assert.Assert(t, is.Len(history, 3))
+	assert.Check(t, history[0].Hit())
+	assert.Check(t, history[1].Missed())
+	assert.Check(t, is.Equal(history[2].Done, 2.0))
+	assert.Check(t, is.Equal(history[1].Start, time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)))
+
+	// the period containing now is in progress until it ends
+	goal.Target = 3
+	history = goal.History(tasks, now, 3)
+	assert.Check(t, history[2].InProgress)
+	assert.Check(t, !history[2].Hit())
+	assert.Check(t, !history[2].Missed())
+	assert.Check(t, !history[1].InProgress)
+
+	// the periods before the goal was created are left out
+	created := now.AddDate(0, 0, -1).Add(-2 * time.Hour)
+	goal.CreatedAt = &created
+	history = goal.History(tasks, now, 3)
+	assert.Assert(t, is.Len(history, 2))
+	assert.Check(t, is.Equal(history[0].Start, time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)))
+
+	created = now.Add(time.Hour)
+	history = goal.History(tasks, now, 3)
+	assert.Assert(t, is.Len(history, 1), "the current period is always shown")
+	assert.Check(t, history[0].InProgress)
+}
+
+func TestGoalValidate(t *testing.T) {
+	assert.NilError(t, Goal{Period: GoalWeekly, Unit: GoalHours, Target: 10}.Validate())
+	assert.ErrorContains(t, Goal{Period: "month", Unit: GoalHours, Target: 10}.Validate(), "unknown goal period")
+	assert.ErrorContains(t, Goal{Period: GoalDaily, Unit: "tasks", Target: 10}.Validate(), "unknown goal unit")
+	assert.ErrorContains(t, Goal{Period: GoalDaily, Unit: GoalHours}.Validate(), "must be positive")
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NoteLastPomodoro refers to the last pomodoro
+// of the task, whichever its number is
+const NoteLastPomodoro = -1
+
+// Note is free text recorded about a task or one of its pomodoros
+type Note struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+	// Number of the pomodoro the note is about counted from 1,
+	// NoteLastPomodoro for the last one and zero for the task
+	Pomodoro int `json:"pomodoro,omitempty"`
+}
+
+// NoteWithID is a unit for requesting
+// a note to be added to a task
+type NoteWithID struct {
+	TaskID int
+	Note   Note
+}
+
+// Validate checks the note has text and refers to
+// a pomodoro of the task, the last pomodoro is
+// replaced by its number
+func (n *Note) Validate(task *Task) error {
+	if n.Text == "" {
+		return errors.New("note text is required")
+	}
+	if n.Pomodoro == NoteLastPomodoro {
+		n.Pomodoro = len(task.Pomodoros)
+		if n.Pomodoro == 0 {
+			return fmt.Errorf("task %d has no pomodoro yet", task.ID)
+		}
+	}
+	if n.Pomodoro < 0 || n.Pomodoro > len(task.Pomodoros) {
+		return fmt.Errorf("task %d has no pomodoro %d", task.ID, n.Pomodoro)
+	}
+	return nil
+}
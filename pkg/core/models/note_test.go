@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestNoteValidate(t *testing.T) {
+	task := &Task{ID: 1, Pomodoros: []*Pomodoro{{}, {}}}
+
+	note := Note{Text: "drafted the intro", Pomodoro: NoteLastPomodoro}
+	assert.NilError(t, note.Validate(task))
+	assert.Check(t, is.Equal(note.Pomodoro, 2))
+
+	assert.NilError(t, (&Note{Text: "about the task"}).Validate(task))
+	assert.ErrorContains(t, (&Note{}).Validate(task), "text is required")
+	assert.ErrorContains(t, (&Note{Text: "x", Pomodoro: 3}).Validate(task), "no pomodoro 3")
+	assert.ErrorContains(t, (&Note{Text: "x", Pomodoro: NoteLastPomodoro}).Validate(&Task{ID: 2}), "no pomodoro yet")
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// BellNotifier rings the terminal bell
+type BellNotifier struct {
+	Out io.Writer
+}
+
+// Notify writes the BEL character to the terminal
+func (n BellNotifier) Notify(string, string) error {
+	out := n.Out
+	if out == nil {
+		out = os.Stderr
+	}
+	_, err := fmt.Fprint(out, "\a")
+	return err
+}
+
+// SoundNotifier plays an audio file with an external
+// player such as paplay, aplay or afplay
+type SoundNotifier struct {
+	// Player command, the file is appended as last argument
+	Player string
+	File   string
+}
+
+// Notify plays the configured file
+func (n SoundNotifier) Notify(string, string) error {
+	args := strings.Fields(n.Player)
+	if len(args) == 0 {
+		return errors.New("no sound player configured")
+	}
+	if n.File == "" {
+		return errors.New("no sound file configured")
+	}
+	args = append(args, n.File)
+	return exec.Command(args[0], args[1:]...).Run()
+}
+
+// CommandNotifier runs a shell command, the title and
+// body are exposed as POMO_TITLE and POMO_BODY
+type CommandNotifier struct {
+	Command string
+}
+
+// Notify runs the configured command
+func (n CommandNotifier) Notify(title, body string) error {
+	if n.Command == "" {
+		return errors.New("no notification command configured")
+	}
+	cmd := exec.Command("sh", "-c", n.Command)
+	cmd.Env = append(os.Environ(), "POMO_TITLE="+title, "POMO_BODY="+body)
+	return cmd.Run()
+}
+
+// MultiNotifier fans a notification out to
+// several notifiers, all of them are tried
+// even if some fail
+type MultiNotifier []Notifier
+
+// Notify sends the notification to every notifier
+func (n MultiNotifier) Notify(title, body string) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.Notify(title, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotificationData is made available to
+// the notification title and body templates
+type NotificationData struct {
+	Message    string
+	Tags       []string
+	Count      int
+	NPomodoros int
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// funcNotifier notifies through a function
+type funcNotifier func(title, body string) error
+
+func (n funcNotifier) Notify(title, body string) error { return n(title, body) }
+
+func TestMultiNotifier(t *testing.T) {
+	var got []string
+	record := func(name string, err error) Notifier {
+		return funcNotifier(func(title, body string) error {
+			got = append(got, name+": "+title+" "+body)
+			return err
+		})
+	}
+	for _, tc := range []struct {
+		name      string
+		notifier  MultiNotifier
+		want      []string
+		wantError []string
+	}{
+		{name: "none", notifier: MultiNotifier{}},
+		{
+			name:     "all",
+			notifier: MultiNotifier{record("a", nil), record("b", nil)},
+			want:     []string{"a: Pomo break", "b: Pomo break"},
+		},
+		{
+			name:      "failures",
+			notifier:  MultiNotifier{record("a", errors.New("a failed")), record("b", nil), record("c", errors.New("c failed"))},
+			want:      []string{"a: Pomo break", "b: Pomo break", "c: Pomo break"},
+			wantError: []string{"a failed", "c failed"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got = nil
+			err := tc.notifier.Notify("Pomo", "break")
+			assert.Check(t, is.DeepEqual(got, tc.want))
+			if len(tc.wantError) == 0 {
+				assert.Check(t, err)
+			}
+			for _, message := range tc.wantError {
+				assert.Check(t, is.ErrorContains(err, message))
+			}
+		})
+	}
+}
+
+func TestBellNotifier(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NilError(t, BellNotifier{Out: out}.Notify("Pomo", "break"))
+	assert.Equal(t, out.String(), "\a")
+}
+
+func TestCommandNotifier(t *testing.T) {
+	file := path.Join(t.TempDir(), "notified")
+	for _, tc := range []struct {
+		name    string
+		command string
+		err     string
+	}{
+		{name: "environment", command: `printf '%s|%s' "$POMO_TITLE" "$POMO_BODY" > ` + file},
+		{name: "failure", command: "exit 3", err: "exit status 3"},
+		{name: "unconfigured", err: "no notification command configured"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CommandNotifier{Command: tc.command}.Notify("Pomo - docs", "break")
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			raw, err := os.ReadFile(file)
+			assert.NilError(t, err)
+			assert.Equal(t, string(raw), "Pomo - docs|break")
+		})
+	}
+}
+
+func TestSoundNotifierConfiguration(t *testing.T) {
+	assert.ErrorContains(t, SoundNotifier{File: "bell.wav"}.Notify("", ""), "no sound player")
+	assert.ErrorContains(t, SoundNotifier{Player: "paplay"}.Notify("", ""), "no sound file")
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PomodoroQuery filters the pomodoros listed,
+// the fields left empty match every pomodoro
+type PomodoroQuery struct {
+	TaskID int
+	// pomodoros started from From and before To
+	From time.Time
+	To   time.Time
+}
+
+// Match returns whether the pomodoro is selected by the query
+func (q PomodoroQuery) Match(pomodoro Pomodoro) bool {
+	if q.TaskID != 0 && pomodoro.TaskID != q.TaskID {
+		return false
+	}
+	if !q.From.IsZero() && pomodoro.Start.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !pomodoro.Start.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Values encodes the query as the
+// parameters of GET /pomodoros
+func (q PomodoroQuery) Values() url.Values {
+	values := url.Values{}
+	if q.TaskID != 0 {
+		values.Set("task_id", strconv.Itoa(q.TaskID))
+	}
+	if !q.From.IsZero() {
+		values.Set("from", q.From.Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		values.Set("to", q.To.Format(time.RFC3339))
+	}
+	return values
+}
+
+// ParsePomodoroQuery reads the parameters of GET /pomodoros
+func ParsePomodoroQuery(values url.Values) (PomodoroQuery, error) {
+	query := PomodoroQuery{}
+	var err error
+	if taskID := values.Get("task_id"); taskID != "" {
+		if query.TaskID, err = strconv.Atoi(taskID); err != nil {
+			return query, err
+		}
+	}
+	if from := values.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return query, err
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+// PomodoroResults is the list of pomodoros returned by the server
+type PomodoroResults struct {
+	Count   int64       `json:"count"`
+	Results []*Pomodoro `json:"results"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPomodoroQueryMatch(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	pomodoro := Pomodoro{TaskID: 2, Start: day.Add(9 * time.Hour), End: day.Add(9*time.Hour + 25*time.Minute)}
+
+	assert.Assert(t, PomodoroQuery{}.Match(pomodoro))
+	assert.Assert(t, PomodoroQuery{TaskID: 2, From: day, To: day.AddDate(0, 0, 1)}.Match(pomodoro))
+	assert.Assert(t, !PomodoroQuery{TaskID: 3}.Match(pomodoro))
+	assert.Assert(t, !PomodoroQuery{From: day.Add(10 * time.Hour)}.Match(pomodoro))
+	assert.Assert(t, !PomodoroQuery{To: day.Add(9 * time.Hour)}.Match(pomodoro), "the end of the range is excluded")
+}
+
+func TestPomodoroQueryValues(t *testing.T) {
+	query := PomodoroQuery{TaskID: 2, From: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}
+	parsed, err := ParsePomodoroQuery(query.Values())
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.TaskID, 2)
+	assert.Assert(t, parsed.From.Equal(query.From))
+	assert.Assert(t, parsed.To.IsZero())
+
+	values := query.Values()
+	values.Set("to", "tomorrow")
+	_, err = ParsePomodoroQuery(values)
+	assert.ErrorContains(t, err, "tomorrow")
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Colors understood by the terminal clients,
+// other colors are given as a 256 color
+// number or as a #rrggbb true color
+var Colors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateColor checks color is empty, one of Colors,
+// a number of the 256 color palette or #rrggbb
+func ValidateColor(color string) error {
+	if color == "" || hexColor.MatchString(color) {
+		return nil
+	}
+	if n, err := strconv.Atoi(color); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	for _, known := range Colors {
+		if color == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color %q, expected one of %v, 0-255 or #rrggbb", color, Colors)
+}
+
+// Project groups tasks, projects can
+// be nested under a parent project
+type Project struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	// Zero for top level projects
+	ParentID int `json:"parent_id,omitempty"`
+	// Archived projects are hidden from the listings
+	Archived bool `json:"archived"`
+}
+
+// Validate checks the project has a name, a known
+// color and a parent found among projects
+func (p Project) Validate(projects Projects) error {
+	if p.Name == "" {
+		return errors.New("project name is required")
+	}
+	if err := ValidateColor(p.Color); err != nil {
+		return err
+	}
+	if p.ParentID != 0 && projects.ByID(p.ParentID) == nil {
+		return fmt.Errorf("parent project %d does not exist", p.ParentID)
+	}
+	return nil
+}
+
+// Projects is a list of projects
+type Projects []Project
+
+// ProjectResults is the list of projects returned by the server
+type ProjectResults struct {
+	Count   int64    `json:"count"`
+	Results Projects `json:"results"`
+}
+
+// ByID returns the project with id, nil when there is none
+func (ps Projects) ByID(id int) *Project {
+	for i := range ps {
+		if ps[i].ID == id {
+			return &ps[i]
+		}
+	}
+	return nil
+}
+
+// Children returns the projects directly under parentID,
+// zero returns the top level projects
+func (ps Projects) Children(parentID int) Projects {
+	children := Projects{}
+	for _, p := range ps {
+		if p.ParentID == parentID {
+			children = append(children, p)
+		}
+	}
+	return children
+}
+
+// Subtree returns the IDs of the project and
+// of every project nested under it
+func (ps Projects) Subtree(id int) map[int]bool {
+	ids := map[int]bool{id: true}
+	// parents may be listed after their children
+	for grown := true; grown; {
+		grown = false
+		for _, p := range ps {
+			if ids[p.ParentID] && !ids[p.ID] {
+				ids[p.ID] = true
+				grown = true
+			}
+		}
+	}
+	return ids
+}
+
+// InProjects returns the tasks belonging to one of the projects
+func InProjects(tasks List, ids map[int]bool) List {
+	filtered := List{}
+	for _, task := range tasks {
+		if ids[task.ProjectID] {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
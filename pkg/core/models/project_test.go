@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestProjectValidate(t *testing.T) {
+	projects := Projects{{ID: 1, Name: "Work"}}
+	assert.NilError(t, Project{Name: "Website", Color: "blue", ParentID: 1}.Validate(projects))
+	assert.NilError(t, Project{Name: "Home", Color: "#00ff7f"}.Validate(projects))
+	assert.ErrorContains(t, Project{}.Validate(projects), "name is required")
+	assert.ErrorContains(t, Project{Name: "Home", Color: "teal"}.Validate(projects), "unknown color")
+	assert.ErrorContains(t, Project{Name: "Home", ParentID: 7}.Validate(projects), "does not exist")
+}
+
+func TestProjectsSubtree(t *testing.T) {
+	projects := Projects{
+		{ID: 1, Name: "Work"},
+		// listed before its parent
+		{ID: 4, Name: "Frontend", ParentID: 3},
+		{ID: 2, Name: "Home"},
+		{ID: 3, Name: "Website", ParentID: 1},
+	}
+	assert.DeepEqual(t, projects.Subtree(1), map[int]bool{1: true, 3: true, 4: true})
+	assert.DeepEqual(t, projects.Subtree(2), map[int]bool{2: true})
+	assert.Check(t, is.Len(projects.Children(0), 2))
+
+	tasks := List{{ID: 1, ProjectID: 4}, {ID: 2, ProjectID: 2}, {ID: 3}}
+	filtered := InProjects(tasks, projects.Subtree(1))
+	assert.Assert(t, is.Len(filtered, 1))
+	assert.Check(t, is.Equal(filtered[0].ID, 1))
+}
@@ -3,6 +3,8 @@ package models
 type Protocol struct {
 	Cid     CmdID
 	Payload Payload
+	// Why the request failed, nil when it succeeded
+	Error *Error `json:",omitempty"`
 }
 
 type Payload interface {
@@ -18,6 +20,30 @@ const (
 	Cmd_GetServerStatus
 	Cmd_GetTask
 	Cmd_UpdateStatus
+	Cmd_SendControl
+	Cmd_GetControl
+	Cmd_CreateGoal
+	Cmd_GetGoals
+	Cmd_DeleteGoal
+	Cmd_CreateProject
+	Cmd_GetProjects
+	Cmd_ArchiveProject
+	Cmd_GetTags
+	Cmd_SetTagColor
+	Cmd_RenameTags
+	Cmd_CreateTemplate
+	Cmd_GetTemplates
+	Cmd_DeleteTemplate
+	Cmd_AddNote
+	Cmd_GetPomodoro
+	Cmd_UpdatePomodoro
+	Cmd_DeletePomodoro
+	Cmd_GetPomodoros
+	Cmd_RestoreTask
+	Cmd_GetTrash
+	Cmd_EmptyTrash
+	Cmd_Sync
+	Cmd_StartTask
 )
 
 const (
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// IdempotencyKeyHeader names the header of the requests
+// that must be applied once however often they are sent
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// QueuedKind is the client call a queued request replays
+type QueuedKind string
+
+const (
+	QueuedTask     QueuedKind = "task"
+	QueuedPomodoro QueuedKind = "pomodoro"
+	QueuedStatus   QueuedKind = "status"
+)
+
+// QueuedRequest is a write the client could not
+// send to the server, kept until it is replayed
+type QueuedRequest struct {
+	// Key sent as the Idempotency-Key header,
+	// the server applies a request only once
+	Key  string     `json:"key"`
+	Time time.Time  `json:"time"`
+	Kind QueuedKind `json:"kind"`
+	// Task of the pomodoro, negative for a task queued too
+	TaskID   int       `json:"task_id,omitempty"`
+	Task     *Task     `json:"task,omitempty"`
+	Pomodoro *Pomodoro `json:"pomodoro,omitempty"`
+	Status   *Status   `json:"status,omitempty"`
+}
+
+// IdempotentResponse is the response recorded for
+// a request so that it can be sent again on a replay
+type IdempotentResponse struct {
+	Key    string
+	Time   time.Time
+	Status int
+	Body   []byte
+}
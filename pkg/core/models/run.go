@@ -0,0 +1,30 @@
+package models
+
+import "fmt"
+
+// Output formats of a headless session
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// RunOptions selects how a client drives a session
+type RunOptions struct {
+	// Headless replaces the UI with events written to
+	// stdout and controls read from stdin and signals
+	Headless bool
+	// Output is the format of the headless events
+	Output string
+	// Detach runs the session headless in a background
+	// process, `pomo attach` shows its UI
+	Detach bool
+}
+
+// Validate checks the output format is known
+func (o RunOptions) Validate() error {
+	switch o.Output {
+	case "", OutputText, OutputJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected %s or %s", o.Output, OutputText, OutputJSON)
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// SyncBatch is what a server sends to another one to sync them
+type SyncBatch struct {
+	// Every task, the trashed ones too, with its pomodoros
+	Tasks []Task `json:"tasks"`
+	// Tasks and pomodoros deleted for good
+	Tombstones []Tombstone `json:"tombstones"`
+	// Notes about the tasks
+	Notes []SyncNote `json:"notes,omitempty"`
+}
+
+// SyncNote is a note about the task with the UUID, notes are
+// only ever added so one is known by its task, time and text
+type SyncNote struct {
+	Task string `json:"task"`
+	Note
+}
+
+// Tombstone records the deletion of a task or a pomodoro
+// so that it is not copied back from another server
+type Tombstone struct {
+	UUID      string    `json:"uuid"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncResult counts the tasks, pomodoros and notes a sync changed
+type SyncResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+// SyncReport is the outcome of a sync with a remote server
+type SyncReport struct {
+	// Changes made to the local server
+	Local SyncResult `json:"local"`
+	// Changes made to the remote server
+	Remote SyncResult `json:"remote"`
+}
+
+// SyncRemote is a request to sync with the server at the URL
+type SyncRemote struct {
+	Remote string `json:"remote"`
+}
+
+// Newer reports whether a change made at updated replaces
+// the one made at current, a missing time is the oldest
+// and an equal time keeps the current change
+func Newer(updated, current *time.Time) bool {
+	if updated == nil {
+		return false
+	}
+	return current == nil || updated.After(*current)
+}
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
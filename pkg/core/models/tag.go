@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tag is a label of tasks and the color it is shown in
+type Tag struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	// Number of tasks with the tag
+	Count int `json:"count"`
+}
+
+// Validate checks the tag has a name that can
+// be stored with the task and a known color
+func (t Tag) Validate() error {
+	if err := validateTagName(t.Name); err != nil {
+		return err
+	}
+	return ValidateColor(t.Color)
+}
+
+func validateTagName(name string) error {
+	if name == "" {
+		return errors.New("tag name is required")
+	}
+	// tags are stored comma separated
+	if strings.Contains(name, ",") {
+		return fmt.Errorf("tag %q can not contain a comma", name)
+	}
+	return nil
+}
+
+// Tags is a list of tags
+type Tags []Tag
+
+// TagResults is the list of tags returned by the server
+type TagResults struct {
+	Count   int64 `json:"count"`
+	Results Tags  `json:"results"`
+}
+
+// ByName returns the tag named name, nil when there is none
+func (ts Tags) ByName(name string) *Tag {
+	for i := range ts {
+		if ts[i].Name == name {
+			return &ts[i]
+		}
+	}
+	return nil
+}
+
+// Colors returns the color of every tag that has one
+func (ts Tags) Colors() map[string]string {
+	colors := map[string]string{}
+	for _, tag := range ts {
+		if tag.Color != "" {
+			colors[tag.Name] = tag.Color
+		}
+	}
+	return colors
+}
+
+// TagRename replaces the From tags of every task by To,
+// renaming a tag or merging several into one
+type TagRename struct {
+	From []string `json:"from"`
+	To   string   `json:"to"`
+}
+
+// Validate checks there are tags to rename to a valid name
+func (r TagRename) Validate() error {
+	if len(r.From) == 0 {
+		return errors.New("no tag to rename")
+	}
+	return validateTagName(r.To)
+}
+
+// Apply returns tags with the From tags replaced by To,
+// keeping a single To when the task had several of them
+func (r TagRename) Apply(tags []string) []string {
+	renamed := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		for _, from := range r.From {
+			if tag == from {
+				tag = r.To
+				break
+			}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			renamed = append(renamed, tag)
+		}
+	}
+	return renamed
+}
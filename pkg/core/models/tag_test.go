@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTagValidate(t *testing.T) {
+	assert.NilError(t, Tag{Name: "docs"}.Validate())
+	assert.NilError(t, Tag{Name: "docs", Color: "208"}.Validate())
+	assert.NilError(t, Tag{Name: "docs", Color: "#ff8700"}.Validate())
+	assert.ErrorContains(t, Tag{Name: "docs", Color: "256"}.Validate(), "unknown color")
+	assert.ErrorContains(t, Tag{Name: "a,b"}.Validate(), "comma")
+	assert.ErrorContains(t, TagRename{To: "docs"}.Validate(), "no tag")
+}
+
+func TestTagRenameApply(t *testing.T) {
+	rename := TagRename{From: []string{"doc", "documentation"}, To: "docs"}
+	assert.DeepEqual(t, rename.Apply([]string{"work", "doc"}), []string{"work", "docs"})
+	// merged tags are kept once
+	assert.DeepEqual(t, rename.Apply([]string{"doc", "docs", "documentation"}), []string{"docs"})
+	assert.DeepEqual(t, rename.Apply([]string{"work"}), []string{"work"})
+}
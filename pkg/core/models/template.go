@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Recurrence is how often a template creates a task
+type Recurrence string
+
+const (
+	// RecurNever only creates tasks on demand
+	RecurNever Recurrence = ""
+	// RecurDaily creates a task every day
+	RecurDaily Recurrence = "daily"
+	// RecurWeekdays creates a task from Monday to Friday
+	RecurWeekdays Recurrence = "weekdays"
+	// RecurWeekly creates a task on Weekday
+	RecurWeekly Recurrence = "weekly"
+)
+
+// Template holds the fields of a task created
+// again and again, on demand or on a schedule
+type Template struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Fields of the tasks created from the template
+	Message    string        `json:"message"`
+	Tags       []string      `json:"tags"`
+	NPomodoros int           `json:"n_pomodoros"`
+	Duration   time.Duration `json:"duration"`
+	ProjectID  int           `json:"project_id,omitempty"`
+	// When the server creates the tasks on its own
+	Recurrence Recurrence   `json:"recurrence,omitempty"`
+	Weekday    time.Weekday `json:"weekday,omitempty"`
+	// Time the last recurring task was created
+	LastCreated time.Time `json:"last_created"`
+}
+
+// Validate checks the template has a unique name, a message
+// for its tasks and a known recurrence
+func (t Template) Validate(templates Templates) error {
+	if t.Name == "" {
+		return errors.New("template name is required")
+	}
+	if templates.ByName(t.Name) != nil {
+		return fmt.Errorf("template %q already exists", t.Name)
+	}
+	if t.Message == "" {
+		return errors.New("template message is required")
+	}
+	if t.NPomodoros <= 0 || t.Duration <= 0 {
+		return errors.New("template pomodoros and duration must be positive")
+	}
+	switch t.Recurrence {
+	case RecurNever, RecurDaily, RecurWeekdays:
+	case RecurWeekly:
+		if t.Weekday < time.Sunday || t.Weekday > time.Saturday {
+			return fmt.Errorf("unknown weekday %d", t.Weekday)
+		}
+	default:
+		return fmt.Errorf("unknown recurrence %q, expected %s, %s or %s", t.Recurrence, RecurDaily, RecurWeekdays, RecurWeekly)
+	}
+	return nil
+}
+
+// Task returns a new task with the fields of the template
+func (t Template) Task() *Task {
+	return &Task{
+		Message:    t.Message,
+		Tags:       append([]string{}, t.Tags...),
+		NPomodoros: t.NPomodoros,
+		Duration:   t.Duration,
+		ProjectID:  t.ProjectID,
+	}
+}
+
+// Due reports whether the recurring task of the day containing
+// now is still to be created, days passed are not caught up
+func (t Template) Due(now time.Time) bool {
+	switch t.Recurrence {
+	case RecurDaily:
+	case RecurWeekdays:
+		if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+			return false
+		}
+	case RecurWeekly:
+		if now.Weekday() != t.Weekday {
+			return false
+		}
+	default:
+		return false
+	}
+	year, month, day := now.Date()
+	return t.LastCreated.Before(time.Date(year, month, day, 0, 0, 0, 0, now.Location()))
+}
+
+// Templates is a list of templates
+type Templates []Template
+
+// TemplateResults is the list of templates returned by the server
+type TemplateResults struct {
+	Count   int64     `json:"count"`
+	Results Templates `json:"results"`
+}
+
+// ByName returns the template named name, nil when there is none
+func (ts Templates) ByName(name string) *Template {
+	for i := range ts {
+		if ts[i].Name == name {
+			return &ts[i]
+		}
+	}
+	return nil
+}
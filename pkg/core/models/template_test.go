@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTemplateValidate(t *testing.T) {
+	templates := Templates{{ID: 1, Name: "standup"}}
+	template := Template{Name: "review", Message: "Code review", NPomodoros: 2, Duration: 25 * time.Minute}
+	assert.NilError(t, template.Validate(templates))
+
+	duplicate := template
+	duplicate.Name = "standup"
+	assert.ErrorContains(t, duplicate.Validate(templates), "already exists")
+	unknown := template
+	unknown.Recurrence = "monthly"
+	assert.ErrorContains(t, unknown.Validate(templates), "unknown recurrence")
+	empty := template
+	empty.NPomodoros = 0
+	assert.ErrorContains(t, empty.Validate(templates), "must be positive")
+}
+
+func TestTemplateDue(t *testing.T) {
+	// a Saturday
+	saturday := time.Date(2021, 1, 16, 9, 0, 0, 0, time.Local)
+	monday := saturday.AddDate(0, 0, 2)
+
+	assert.Assert(t, !Template{}.Due(monday), "templates without recurrence are created on demand")
+	assert.Assert(t, Template{Recurrence: RecurDaily}.Due(saturday))
+	assert.Assert(t, !Template{Recurrence: RecurWeekdays}.Due(saturday))
+	assert.Assert(t, Template{Recurrence: RecurWeekdays}.Due(monday))
+	assert.Assert(t, Template{Recurrence: RecurWeekly, Weekday: time.Monday}.Due(monday))
+	assert.Assert(t, !Template{Recurrence: RecurWeekly, Weekday: time.Monday}.Due(saturday))
+
+	created := Template{Recurrence: RecurDaily, LastCreated: monday.Add(-time.Hour)}
+	assert.Assert(t, !created.Due(monday), "already created today")
+	assert.Assert(t, created.Due(monday.AddDate(0, 0, 1)))
+}
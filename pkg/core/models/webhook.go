@@ -0,0 +1,95 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies the kind of event
+// published to outgoing webhooks
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task.created"
+	EventTaskDeleted       EventType = "task.deleted"
+	EventPomodoroStarted   EventType = "pomodoro.started"
+	EventPomodoroCompleted EventType = "pomodoro.completed"
+	EventSessionPaused     EventType = "session.paused"
+	EventSessionResumed    EventType = "session.resumed"
+	EventSessionCompleted  EventType = "session.completed"
+	// EventPing is only sent by `pomo server webhooks test`
+	EventPing EventType = "ping"
+)
+
+// StatusEvents returns the events implied by the runner moving from
+// prev to next, a pomodoro is completed when the count goes up
+func StatusEvents(prev, next Status) []EventType {
+	if prev.State == next.State && prev.Count == next.Count {
+		return nil
+	}
+	var events []EventType
+	if next.Count > prev.Count {
+		events = append(events, EventPomodoroCompleted)
+	}
+	switch next.State {
+	case RUNNING:
+		if prev.State == PAUSED {
+			events = append(events, EventSessionResumed)
+		} else {
+			events = append(events, EventPomodoroStarted)
+		}
+	case PAUSED:
+		events = append(events, EventSessionPaused)
+	case COMPLETE:
+		events = append(events, EventSessionCompleted)
+	}
+	return events
+}
+
+// Event is the JSON document posted to
+// every webhook endpoint subscribed to its type
+type Event struct {
+	ID   string          `json:"id"`
+	Type EventType       `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// WebhookEndpoint is a configured receiver of events
+type WebhookEndpoint struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Secret string   `json:"-"`
+	Events []string `json:"events"`
+}
+
+// Accepts reports whether the endpoint is subscribed
+// to the given event type. An endpoint without
+// events receives everything.
+func (e WebhookEndpoint) Accepts(eventType EventType) bool {
+	if len(e.Events) == 0 || eventType == EventPing {
+		return true
+	}
+	for _, event := range e.Events {
+		if event == "*" || EventType(event) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is a queued attempt to
+// post an event to a single endpoint
+type WebhookDelivery struct {
+	ID       int    `json:"id"`
+	Endpoint string `json:"endpoint"`
+	Event    Event  `json:"event"`
+	// Number of attempts made so far
+	Attempts int `json:"attempts"`
+	// Earliest time of the next attempt,
+	// the time of the last one once Failed is set
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	// Failed is set once all attempts were exhausted
+	Failed bool `json:"failed"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestStatusEvents(t *testing.T) {
+	testcases := []struct {
+		doc      string
+		prev     Status
+		next     Status
+		expected []EventType
+	}{
+		{doc: "start", next: Status{State: RUNNING}, expected: []EventType{EventPomodoroStarted}},
+		{doc: "break over", prev: Status{State: BREAKING, Count: 1}, next: Status{State: RUNNING, Count: 1}, expected: []EventType{EventPomodoroStarted}},
+		{doc: "pause", prev: Status{State: RUNNING}, next: Status{State: PAUSED}, expected: []EventType{EventSessionPaused}},
+		{doc: "resume", prev: Status{State: PAUSED}, next: Status{State: RUNNING}, expected: []EventType{EventSessionResumed}},
+		{doc: "pomodoro done", prev: Status{State: RUNNING}, next: Status{State: BREAKING, Count: 1}, expected: []EventType{EventPomodoroCompleted}},
+		{doc: "last pomodoro done", prev: Status{State: RUNNING, Count: 1}, next: Status{State: COMPLETE, Count: 2}, expected: []EventType{EventPomodoroCompleted, EventSessionCompleted}},
+		{doc: "complete", prev: Status{State: BREAKING}, next: Status{State: COMPLETE}, expected: []EventType{EventSessionCompleted}},
+		{doc: "tick", prev: Status{State: RUNNING}, next: Status{State: RUNNING}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.doc, func(t *testing.T) {
+			assert.DeepEqual(t, StatusEvents(tc.prev, tc.next), tc.expected)
+		})
+	}
+}
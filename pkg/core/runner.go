@@ -1,6 +1,7 @@
 package core
 
 import (
+	"io"
 	"time"
 
 	"github.com/joaorufino/pomo/pkg/core/models"
@@ -13,6 +14,11 @@ type Runner interface {
 	Status() *models.Status
 	Toggle()
 	Pause()
+	Skip()
+	Extend(duration time.Duration)
+	Restart()
+	Stop()
 	Start()
 	StartUI()
+	StartHeadless(in io.Reader, out io.Writer, output string) error
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/joaorufino/pomo/pkg/core/models"
 )
@@ -11,11 +12,66 @@ type Store interface {
 	TaskGetByID(ctx context.Context, id int) (*models.Task, error)
 	GetAllTasks(ctx context.Context) (models.List, error)
 	TaskSave(ctx context.Context, task *models.Task) (int, error)
+	// TaskDeleteByID moves the task to the trash
 	TaskDeleteByID(ctx context.Context, id int) error
+	TaskRestoreByID(ctx context.Context, id int) error
+	TrashList(ctx context.Context) (models.List, error)
+	// TrashPurge deletes the tasks moved to the trash before the time
+	TrashPurge(ctx context.Context, before time.Time) ([]int, error)
 
 	PomodoroGetByTaskID(ctx context.Context, id int) ([]*models.Pomodoro, error)
 	PomodoroSave(ctx context.Context, taskID int, pomodoro *models.Pomodoro) error
 	PomodoroDeleteByTaskID(ctx context.Context, id int) error
+	PomodoroList(ctx context.Context, query models.PomodoroQuery) ([]*models.Pomodoro, error)
+	PomodoroGetByID(ctx context.Context, id int) (*models.Pomodoro, error)
+	PomodoroUpdate(ctx context.Context, pomodoro *models.Pomodoro) error
+	PomodoroDeleteByID(ctx context.Context, id int) error
+
+	// NoteSave records a note about the task or one of its pomodoros
+	NoteSave(ctx context.Context, taskID int, note *models.Note) error
+	NoteGetByTaskID(ctx context.Context, id int) ([]models.Note, error)
+
+	WebhookDeliverySave(ctx context.Context, delivery *models.WebhookDelivery) (int, error)
+	WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error
+	WebhookDeliveryDeleteByID(ctx context.Context, id int) error
+	WebhookDeliveryList(ctx context.Context) ([]*models.WebhookDelivery, error)
+	// WebhookDeliveryPurge deletes the deliveries that failed before the time
+	WebhookDeliveryPurge(ctx context.Context, before time.Time) (int, error)
+
+	GoalSave(ctx context.Context, goal *models.Goal) (int, error)
+	GoalList(ctx context.Context) ([]models.Goal, error)
+	GoalDeleteByID(ctx context.Context, id int) error
+
+	ProjectSave(ctx context.Context, project *models.Project) (int, error)
+	ProjectList(ctx context.Context) (models.Projects, error)
+	ProjectArchiveByID(ctx context.Context, id int) error
+
+	TagList(ctx context.Context) (models.Tags, error)
+	TagSave(ctx context.Context, tag *models.Tag) error
+	TagRename(ctx context.Context, rename models.TagRename) error
+
+	TemplateSave(ctx context.Context, template *models.Template) (int, error)
+	TemplateList(ctx context.Context) (models.Templates, error)
+	TemplateDeleteByID(ctx context.Context, id int) error
+	// TemplateMaterialise saves the task of the template and
+	// records the time the recurring task was last created
+	TemplateMaterialise(ctx context.Context, id int, task *models.Task, created time.Time) (int, error)
+
+	// Backup copies the database to a new file at the path
+	Backup(ctx context.Context, path string) error
+	// Restore replaces the database with the backup at the path
+	Restore(ctx context.Context, path string) error
+	Vacuum(ctx context.Context) error
+	Check(ctx context.Context) (*models.DatabaseCheck, error)
+
+	// SyncExport returns what another server needs to sync with this one
+	SyncExport(ctx context.Context) (*models.SyncBatch, error)
+	// SyncMerge applies the batch exported by another server
+	SyncMerge(ctx context.Context, batch *models.SyncBatch) (*models.SyncResult, error)
+
+	// IdempotentResponseGet returns the response recorded for a request key
+	IdempotentResponseGet(ctx context.Context, key string) (*models.IdempotentResponse, error)
+	IdempotentResponseSave(ctx context.Context, response *models.IdempotentResponse) error
 	Close() error
 	InitDB() error
 }
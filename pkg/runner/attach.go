@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"errors"
+	"time"
+
+	termui "github.com/gizak/termui/v3"
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+const attachHelpText = "[enter] next pomodoro  [p] pause  [n] skip  [e] extend  [r] restart  [x] stop  [q] detach"
+
+// Attach shows the dashboard of a session run by another process,
+// keys are relayed to it as controls through the server. Quitting
+// detaches the UI and leaves the session running.
+func Attach(client core.Client) error {
+	status, err := client.GetServerStatus()
+	if err != nil {
+		return err
+	}
+	if !status.State.Active() {
+		return errors.New("no session is running")
+	}
+
+	err = termui.Init()
+	if err != nil {
+		return err
+	}
+	defer termui.Close()
+
+	wheel := models.Wheel(0)
+	d := newDashboard()
+	d.help.Text = attachHelpText
+	var tasks models.List
+	refresh := func() {
+		list, err := client.GetTaskList()
+		if err == nil {
+			tasks = *list
+		}
+		if goals, err := client.GetGoals(); err == nil {
+			d.goals = goals
+		}
+	}
+	// The runner reports the remaining time every
+	// few seconds, count down locally in between
+	received, receivedAt := *status, time.Now()
+	draw := func() {
+		shown := received
+		if shown.State == models.RUNNING || shown.State == models.BREAKING {
+			shown.Remaining = (received.Remaining - time.Since(receivedAt)).Truncate(time.Second)
+			if shown.Remaining < 0 {
+				shown.Remaining = 0
+			}
+		}
+		d.update(&wheel, &shown, taskMessage(tasks, shown.TaskID), shown.TaskID, tasks, time.Now())
+		d.layout(termui.TerminalDimensions())
+		termui.Render(d.grid)
+	}
+	send := func(control models.Control) {
+		d.help.Text = attachHelpText
+		if err := client.SendControl(control); err != nil {
+			d.help.Text = attachHelpText + "\n" + err.Error()
+		}
+	}
+	refresh()
+	draw()
+
+	uiEvents := termui.PollEvents()
+	ticker := time.NewTicker(time.Second).C
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.ID {
+			case "<Enter>":
+				send(models.Control{Action: models.ControlNext})
+			case "q", "<C-c>":
+				return nil
+			case "p":
+				send(models.Control{Action: models.ControlPause})
+			case "n":
+				send(models.Control{Action: models.ControlSkip})
+			case "e":
+				send(models.Control{Action: models.ControlExtend, Duration: extendDuration})
+			case "r":
+				send(models.Control{Action: models.ControlRestart})
+			case "x":
+				send(models.Control{Action: models.ControlStop})
+			case "<Down>", "j":
+				d.tasks.ScrollDown()
+			case "<Up>", "k":
+				d.tasks.ScrollUp()
+			}
+			draw()
+		case <-ticker:
+			status, err := client.GetServerStatus()
+			if err == nil && *status != received {
+				// reload the tasks once a pomodoro is saved
+				if status.State != received.State || status.Count != received.Count {
+					refresh()
+				}
+				received, receivedAt = *status, time.Now()
+			}
+			draw()
+		}
+	}
+}
+
+// taskMessage returns the message of the task with taskID
+func taskMessage(tasks models.List, taskID int) string {
+	for _, task := range tasks {
+		if task.ID == taskID {
+			return task.Message
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// autoStart is how breaks and the pomodoros
+// following them start without the user
+type autoStart struct {
+	// breaks are timed from the end of the pomodoro
+	breaks bool
+	// the next pomodoro starts once the break
+	// elapses, which times the breaks too
+	pomodoros bool
+	// length of the timed breaks
+	duration time.Duration
+	// how long before a pomodoro starts on its own the user is warned
+	warning time.Duration
+}
+
+// newAutoStart parses the auto-start configuration
+func newAutoStart(config conf.AutoStartConfig) (autoStart, error) {
+	a := autoStart{breaks: config.Break, pomodoros: config.Pomodoro}
+	var err error
+	if config.Duration != "" {
+		if a.duration, err = time.ParseDuration(config.Duration); err != nil {
+			return a, fmt.Errorf("invalid break duration: %w", err)
+		}
+	}
+	if config.Warning != "" {
+		if a.warning, err = time.ParseDuration(config.Warning); err != nil {
+			return a, fmt.Errorf("invalid auto-start warning: %w", err)
+		}
+	}
+	if a.pomodoros && a.duration <= 0 {
+		return a, fmt.Errorf("auto-starting the pomodoros needs a break duration")
+	}
+	return a, nil
+}
+
+// forTask applies the options set on task
+func (a autoStart) forTask(task *models.Task) autoStart {
+	if task.BreakDuration > 0 {
+		a.duration = task.BreakDuration
+	}
+	if task.AutoStartBreak != nil {
+		a.breaks = *task.AutoStartBreak
+	}
+	if task.AutoStartPomodoro != nil {
+		a.pomodoros = *task.AutoStartPomodoro
+	}
+	return a
+}
+
+// timed reports whether breaks run on a timer, they
+// do when the pomodoros following them auto-start
+func (a autoStart) timed() bool {
+	return (a.breaks || a.pomodoros) && a.duration > 0
+}
+
+// breakTimers fire when a timed break elapses
+// and when the user is warned ahead of it
+type breakTimers struct {
+	end     *time.Timer
+	warning *time.Timer
+}
+
+// newBreakTimers starts the timers of a break,
+// both stay disarmed for breaks that are not timed
+func newBreakTimers(a autoStart) *breakTimers {
+	b := &breakTimers{}
+	if !a.timed() {
+		return b
+	}
+	b.end = time.NewTimer(a.duration)
+	if a.pomodoros && a.warning > 0 && a.warning < a.duration {
+		b.warning = time.NewTimer(a.duration - a.warning)
+	}
+	return b
+}
+
+// endC returns the channel of the end timer, nil when disarmed
+func (b *breakTimers) endC() <-chan time.Time {
+	if b.end == nil {
+		return nil
+	}
+	return b.end.C
+}
+
+// warningC returns the channel of the warning timer, nil when disarmed
+func (b *breakTimers) warningC() <-chan time.Time {
+	if b.warning == nil {
+		return nil
+	}
+	return b.warning.C
+}
+
+// stop disarms both timers
+func (b *breakTimers) stop() {
+	if b.end != nil {
+		b.end.Stop()
+		b.end = nil
+	}
+	if b.warning != nil {
+		b.warning.Stop()
+		b.warning = nil
+	}
+}
@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestAutoStartNextPomodoro(t *testing.T) {
+	notifier := &recordingNotifier{}
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), notifier)
+	assert.NilError(t, err)
+	runner.autoStart = autoStart{breaks: true, pomodoros: true, duration: 100 * time.Millisecond, warning: 50 * time.Millisecond}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING })
+	// the break elapses and the next pomodoro starts on its own
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING && s.Count == 1 })
+	// the break notification and the warning
+	assert.Equal(t, notifier.count(), 2)
+	assert.Check(t, runner.Status().Remaining > 50*time.Minute)
+
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+}
+
+func TestAutoStartPomodoroOnly(t *testing.T) {
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), &recordingNotifier{})
+	assert.NilError(t, err)
+	runner.autoStart = autoStart{pomodoros: true, duration: 50 * time.Millisecond}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING })
+	// the break is timed although break auto-start is off
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING && s.Count == 1 })
+
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+}
+
+func TestTimedBreakWaitsForUser(t *testing.T) {
+	notifier := &recordingNotifier{}
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), notifier)
+	assert.NilError(t, err)
+	runner.autoStart = autoStart{breaks: true, duration: 50 * time.Millisecond}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING })
+	// the user is told once the break is over
+	waitForStatus(t, runner, func(*models.Status) bool { return notifier.count() == 2 })
+	assert.Check(t, is.Equal(runner.Status().State, models.BREAKING))
+	assert.Check(t, is.Equal(runner.Status().Remaining, time.Duration(0)))
+
+	runner.Toggle()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING && s.Count == 1 })
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+}
+
+func TestAutoStartForTask(t *testing.T) {
+	config, err := newAutoStart(conf.AutoStartConfig{Break: true, Duration: "5m", Warning: "30s"})
+	assert.NilError(t, err)
+	assert.Check(t, config.timed())
+
+	no := false
+	task := &models.Task{BreakDuration: 10 * time.Minute, AutoStartBreak: &no}
+	assert.Check(t, is.Equal(config.forTask(task), autoStart{duration: 10 * time.Minute, warning: 30 * time.Second}))
+	assert.Check(t, is.Equal(config.forTask(&models.Task{}), config))
+
+	yes := true
+	assert.Check(t, config.forTask(&models.Task{AutoStartBreak: &no, AutoStartPomodoro: &yes}).timed())
+
+	_, err = newAutoStart(conf.AutoStartConfig{Duration: "later"})
+	assert.ErrorContains(t, err, "invalid break duration")
+	_, err = newAutoStart(conf.AutoStartConfig{Pomodoro: true})
+	assert.ErrorContains(t, err, "needs a break duration")
+}
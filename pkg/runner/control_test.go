@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/test"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func waitForStatus(t *testing.T, runner *TaskRunner, check func(*models.Status) bool) {
+	t.Helper()
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if status := runner.Status(); !check(status) {
+			return poll.Continue("status is %+v", status)
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second), poll.WithDelay(5*time.Millisecond))
+}
+
+func TestTaskRunnerControls(t *testing.T) {
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), models.NoopNotifier{})
+	assert.NilError(t, err)
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Extend(10 * time.Minute)
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.Remaining > time.Hour })
+
+	runner.Restart()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.Remaining <= time.Hour })
+
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING && s.Count == 1 })
+
+	// skipping the break starts the next pomodoro
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE && s.Count == 1 })
+
+	// controls without a session are dropped
+	runner.Skip()
+	assert.Equal(t, runner.Status().State, models.COMPLETE)
+}
+
+func TestTaskRunnerRelayedControls(t *testing.T) {
+	client := test.NewMockClient(nil, test.MockClientOptions{})
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 1,
+		Message:    "Test Task",
+	}, client, models.NoopNotifier{})
+	assert.NilError(t, err)
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	assert.NilError(t, client.SendControl(models.Control{Action: models.ControlStop}))
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+}
+
+func TestPauseAndToggleDoNotBlock(t *testing.T) {
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 1,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), models.NoopNotifier{})
+	assert.NilError(t, err)
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+
+	// the session ended after the UI checked its state
+	done := make(chan struct{})
+	go func() {
+		runner.Pause()
+		runner.Toggle()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Pause and Toggle block once the session is over")
+	}
+}
+
+func TestControlValidate(t *testing.T) {
+	assert.NilError(t, models.Control{Action: models.ControlSkip}.Validate())
+	assert.NilError(t, models.Control{Action: models.ControlExtend, Duration: time.Minute}.Validate())
+	assert.ErrorContains(t, models.Control{Action: models.ControlExtend}.Validate(), "positive duration")
+	assert.ErrorContains(t, models.Control{Action: "rewind"}.Validate(), "unknown control action")
+}
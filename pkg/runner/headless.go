@@ -0,0 +1,189 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+const (
+	// eventStatus answers the status command
+	eventStatus models.EventType = "status"
+	// eventError reports a command that could not run
+	eventError models.EventType = "error"
+)
+
+// headlessInterval is how often a headless
+// session checks the runner for changes
+const headlessInterval = 250 * time.Millisecond
+
+// headlessEvent is written for every change of a headless session
+type headlessEvent struct {
+	Type    models.EventType `json:"type"`
+	Time    time.Time        `json:"time"`
+	TaskID  int              `json:"task_id"`
+	Message string           `json:"message"`
+	Status  models.Status    `json:"status"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// StartHeadless drives the session without a terminal UI. Events are
+// written to out as lines of text or JSON, commands are read one per
+// line from in and SIGUSR1 pauses while SIGUSR2 skips. It returns once
+// the session completes or is stopped by SIGINT or SIGTERM.
+func (runner *TaskRunner) StartHeadless(in io.Reader, out io.Writer, output string) error {
+	emit := func(eventType models.EventType, status models.Status, err error) {
+		event := headlessEvent{
+			Type:    eventType,
+			Time:    time.Now(),
+			TaskID:  runner.TaskID(),
+			Message: runner.message(),
+			Status:  status,
+		}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		writeEvent(out, output, event)
+	}
+
+	signals := make(chan os.Signal, 1)
+	watched := []os.Signal{os.Interrupt, syscall.SIGTERM}
+	for sig := range signalCommands {
+		watched = append(watched, sig)
+	}
+	signal.Notify(signals, watched...)
+	defer signal.Stop(signals)
+
+	commands := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			commands <- scanner.Text()
+		}
+		close(commands)
+	}()
+
+	var last models.Status
+	// changed emits the events since the last check
+	// and reports whether the session is over
+	changed := func() bool {
+		status := *runner.Status()
+		for _, eventType := range models.StatusEvents(last, status) {
+			emit(eventType, status, nil)
+		}
+		last = status
+		return status.State == models.COMPLETE
+	}
+	changed()
+
+	ticker := time.NewTicker(headlessInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case sig := <-signals:
+			if command, ok := signalCommands[sig]; ok {
+				if err := runner.command(command); err != nil {
+					emit(eventError, *runner.Status(), err)
+				}
+				continue
+			}
+			runner.Stop()
+			runner.waitFor(models.COMPLETE, 5*time.Second)
+			emit(models.EventSessionCompleted, *runner.Status(), nil)
+			return nil
+		case line, ok := <-commands:
+			if !ok {
+				// Keep running without stdin,
+				// eg: under systemd
+				commands = nil
+				continue
+			}
+			if strings.TrimSpace(line) == "status" {
+				emit(eventStatus, *runner.Status(), nil)
+			} else if err := runner.command(line); err != nil {
+				emit(eventError, *runner.Status(), err)
+			}
+		case <-ticker.C:
+			if changed() {
+				return nil
+			}
+		}
+	}
+}
+
+// command runs one line read by a headless session
+func (runner *TaskRunner) command(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	state := runner.Status().State
+	if !state.Active() {
+		return fmt.Errorf("no session is running")
+	}
+	switch fields[0] {
+	case "pause", "resume":
+		runner.Pause()
+	case "next":
+		if state != models.BREAKING {
+			return fmt.Errorf("next only applies during a break")
+		}
+		runner.Toggle()
+	case "skip":
+		runner.Skip()
+	case "extend":
+		duration := extendDuration
+		if len(fields) > 1 {
+			parsed, err := time.ParseDuration(fields[1])
+			if err != nil {
+				return err
+			}
+			duration = parsed
+		}
+		control := models.Control{Action: models.ControlExtend, Duration: duration}
+		if err := control.Validate(); err != nil {
+			return err
+		}
+		runner.Apply(control)
+	case "restart":
+		runner.Restart()
+	case "stop":
+		runner.Stop()
+	default:
+		return fmt.Errorf("unknown command %q, expected pause, resume, next, skip, extend [duration], restart, stop or status", fields[0])
+	}
+	return nil
+}
+
+// waitFor polls the runner until it reaches state or timeout passes
+func (runner *TaskRunner) waitFor(state models.State, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for runner.Status().State != state && time.Now().Before(deadline) {
+		time.Sleep(headlessInterval)
+	}
+}
+
+// writeEvent writes event as one line in the output format
+func writeEvent(out io.Writer, output string, event headlessEvent) {
+	if output == models.OutputJSON {
+		raw, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(out, "%s\n", raw)
+		return
+	}
+	line := fmt.Sprintf("%s %s %s - %s", event.Time.Format(time.RFC3339), event.Type, FormatStatus(event.Status), event.Message)
+	if event.Error != "" {
+		line += ": " + event.Error
+	}
+	fmt.Fprintln(out, line)
+}
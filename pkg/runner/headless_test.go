@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestStartHeadless(t *testing.T) {
+	runner, err := NewMockedTaskRunner(&models.Task{
+		ID:         3,
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), models.NoopNotifier{})
+	assert.NilError(t, err)
+	runner.Start()
+
+	in, stdin := io.Pipe()
+	out := &bytes.Buffer{}
+	done := make(chan error)
+	go func() { done <- runner.StartHeadless(in, out, models.OutputJSON) }()
+
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+	for _, line := range []string{"extend 10m", "status", "rewind"} {
+		_, err := io.WriteString(stdin, line+"\n")
+		assert.NilError(t, err)
+	}
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.Remaining > time.Hour })
+	_, err = io.WriteString(stdin, "skip\n")
+	assert.NilError(t, err)
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING })
+	time.Sleep(2 * headlessInterval)
+	_, err = io.WriteString(stdin, "stop\n")
+	assert.NilError(t, err)
+
+	select {
+	case err := <-done:
+		assert.NilError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("headless session did not return")
+	}
+
+	var events []models.EventType
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		event := headlessEvent{}
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &event))
+		assert.Equal(t, event.TaskID, 3)
+		events = append(events, event.Type)
+	}
+	assert.DeepEqual(t, events, []models.EventType{
+		models.EventPomodoroStarted,
+		eventStatus,
+		eventError,
+		models.EventPomodoroCompleted,
+		models.EventSessionCompleted,
+	})
+}
+
+func TestWriteEventText(t *testing.T) {
+	out := &bytes.Buffer{}
+	writeEvent(out, models.OutputText, headlessEvent{
+		Type:    eventError,
+		Time:    time.Date(2021, 1, 16, 19, 5, 21, 0, time.UTC),
+		Message: "Test Task",
+		Status:  models.Status{State: models.BREAKING, Count: 1, NPomodoros: 2},
+		Error:   "next only applies during a break",
+	})
+	assert.Check(t, is.Equal(strings.TrimSpace(out.String()), "2021-01-16T19:05:21Z error B [1/2] - - Test Task: next only applies during a break"))
+}
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+)
+
+// Actions taken once a session is idle
+const (
+	idleRemind  = "remind"
+	idleAbandon = "abandon"
+)
+
+// idlePolicy is what happens to a session left
+// paused or on a break for longer than timeout
+type idlePolicy struct {
+	// zero waits for the user forever
+	timeout time.Duration
+	// abandon ends the session, otherwise the
+	// user is reminded every interval
+	abandon  bool
+	interval time.Duration
+}
+
+// newIdlePolicy parses the configuration of an idle policy
+func newIdlePolicy(config conf.IdleConfig) (idlePolicy, error) {
+	policy := idlePolicy{}
+	var err error
+	if config.Timeout != "" {
+		if policy.timeout, err = time.ParseDuration(config.Timeout); err != nil {
+			return policy, fmt.Errorf("invalid idle timeout: %w", err)
+		}
+	}
+	if config.Interval != "" {
+		if policy.interval, err = time.ParseDuration(config.Interval); err != nil {
+			return policy, fmt.Errorf("invalid reminder interval: %w", err)
+		}
+	}
+	switch config.Action {
+	case "", idleRemind:
+	case idleAbandon:
+		policy.abandon = true
+	default:
+		return policy, fmt.Errorf("unknown idle action %q, expected %s or %s", config.Action, idleRemind, idleAbandon)
+	}
+	return policy, nil
+}
+
+// idleTimer fires while the session waits on the user
+type idleTimer struct {
+	policy idlePolicy
+	timer  *time.Timer
+	since  time.Time
+}
+
+// start arms the timer with policy
+func (i *idleTimer) start(policy idlePolicy) {
+	i.stop()
+	i.policy = policy
+	i.since = time.Now()
+	if policy.timeout > 0 {
+		i.timer = time.NewTimer(policy.timeout)
+	}
+}
+
+// stop disarms the timer
+func (i *idleTimer) stop() {
+	if i.timer != nil {
+		i.timer.Stop()
+		i.timer = nil
+	}
+}
+
+// C returns the channel of the timer, a nil
+// channel blocks forever when it is disarmed
+func (i *idleTimer) C() <-chan time.Time {
+	if i.timer == nil {
+		return nil
+	}
+	return i.timer.C
+}
+
+// fired reports whether the session has to be abandoned,
+// otherwise the timer is rearmed for the next reminder
+func (i *idleTimer) fired() bool {
+	if i.policy.abandon {
+		i.stop()
+		return true
+	}
+	if i.policy.interval > 0 {
+		i.timer.Reset(i.policy.interval)
+	} else {
+		i.timer = nil
+	}
+	return false
+}
+
+// idle returns how long the session has been waiting
+func (i *idleTimer) idle() time.Duration {
+	return time.Since(i.since).Truncate(time.Second)
+}
@@ -0,0 +1,134 @@
+package runner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/poll"
+)
+
+// recordingNotifier keeps the bodies of the notifications
+type recordingNotifier struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (n *recordingNotifier) Notify(title, body string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.bodies = append(n.bodies, body)
+	return nil
+}
+
+func (n *recordingNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.bodies)
+}
+
+func TestPauseTimeoutAbandons(t *testing.T) {
+	notifier := &recordingNotifier{}
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), notifier)
+	assert.NilError(t, err)
+	runner.pauseIdle = idlePolicy{timeout: 20 * time.Millisecond, abandon: true}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Pause()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+	assert.Equal(t, notifier.count(), 1)
+}
+
+// pomodoroClient records the pomodoros the runner saves
+type pomodoroClient struct {
+	core.Client
+	mu        sync.Mutex
+	pomodoros []models.Pomodoro
+}
+
+func (c *pomodoroClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pomodoros = append(c.pomodoros, pomodoro)
+	return nil
+}
+
+func TestPauseTimeoutEndsPomodoroWhenPaused(t *testing.T) {
+	client := &pomodoroClient{Client: test.NewMockClient(nil, test.MockClientOptions{})}
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, client, &recordingNotifier{})
+	assert.NilError(t, err)
+	runner.pauseIdle = idlePolicy{timeout: 200 * time.Millisecond, abandon: true}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	beforePause := time.Now()
+	runner.Pause()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.PAUSED })
+	afterPause := time.Now()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	assert.Assert(t, is.Len(client.pomodoros, 1))
+	end := client.pomodoros[0].End
+	// the time left paused is not counted
+	assert.Check(t, !end.Before(beforePause), "%s before %s", end, beforePause)
+	assert.Check(t, !end.After(afterPause), "%s after %s", end, afterPause)
+}
+
+func TestBreakTimeoutReminds(t *testing.T) {
+	notifier := &recordingNotifier{}
+	runner, err := NewMockedTaskRunner(&models.Task{
+		Duration:   time.Hour,
+		NPomodoros: 2,
+		Message:    "Test Task",
+	}, test.NewMockClient(nil, test.MockClientOptions{}), notifier)
+	assert.NilError(t, err)
+	runner.breakIdle = idlePolicy{timeout: 20 * time.Millisecond, interval: 20 * time.Millisecond}
+	runner.Start()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.RUNNING })
+
+	runner.Skip()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.BREAKING })
+	// the break notification and two reminders
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if n := notifier.count(); n < 3 {
+			return poll.Continue("%d notifications sent", n)
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second), poll.WithDelay(5*time.Millisecond))
+	assert.Equal(t, runner.Status().State, models.BREAKING)
+
+	runner.Stop()
+	waitForStatus(t, runner, func(s *models.Status) bool { return s.State == models.COMPLETE })
+}
+
+func TestNewIdlePolicy(t *testing.T) {
+	policy, err := newIdlePolicy(conf.IdleConfig{Timeout: "1h", Action: "abandon"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(policy, idlePolicy{timeout: time.Hour, abandon: true}))
+
+	policy, err = newIdlePolicy(conf.IdleConfig{})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(policy.timeout, time.Duration(0)))
+
+	_, err = newIdlePolicy(conf.IdleConfig{Timeout: "soon"})
+	assert.ErrorContains(t, err, "invalid idle timeout")
+	_, err = newIdlePolicy(conf.IdleConfig{Action: "snooze"})
+	assert.ErrorContains(t, err, "unknown idle action")
+}
@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"github.com/joaorufino/pomo/pkg/conf"
 	"github.com/joaorufino/pomo/pkg/core"
 	"github.com/joaorufino/pomo/pkg/core/models"
 )
@@ -9,14 +10,18 @@ func NewMockedTaskRunner(task *models.Task, client core.Client, notifier models.
 	tr := &TaskRunner{
 		taskID:       task.ID,
 		taskMessage:  task.Message,
+		taskTags:     task.Tags,
 		nPomodoros:   task.NPomodoros,
 		origDuration: task.Duration,
 		client:       client,
 		state:        models.State(0),
-		pause:        make(chan bool),
-		toggle:       make(chan bool),
+		switchTask:   make(chan *models.Task),
+		control:      make(chan models.Control, 1),
 		notifier:     notifier,
 		duration:     task.Duration,
 	}
+	if err := tr.setNotifications(conf.NotifierConfig{}); err != nil {
+		return nil, err
+	}
 	return tr, nil
 }
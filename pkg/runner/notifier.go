@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// NotifierFactory builds a notifier backend from the configuration
+type NotifierFactory func(config conf.NotifierConfig) (models.Notifier, error)
+
+var notifiers = map[string]NotifierFactory{
+	"desktop": func(config conf.NotifierConfig) (models.Notifier, error) {
+		return models.NewXnotifier(config.Icon), nil
+	},
+	"bell": func(config conf.NotifierConfig) (models.Notifier, error) {
+		return models.BellNotifier{}, nil
+	},
+	"sound": func(config conf.NotifierConfig) (models.Notifier, error) {
+		return models.SoundNotifier{Player: config.Player, File: config.Sound}, nil
+	},
+	"command": func(config conf.NotifierConfig) (models.Notifier, error) {
+		return models.CommandNotifier{Command: config.Command}, nil
+	},
+	"noop": func(config conf.NotifierConfig) (models.Notifier, error) {
+		return models.NoopNotifier{}, nil
+	},
+}
+
+// RegisterNotifier makes a notifier backend available
+// to the notifier.backends configuration under name
+func RegisterNotifier(name string, factory NotifierFactory) {
+	notifiers[name] = factory
+}
+
+// NewNotifier builds the backends enabled in the configuration,
+// fanning out to all of them when more than one is enabled
+func NewNotifier(config conf.NotifierConfig) (models.Notifier, error) {
+	multi := models.MultiNotifier{}
+	for _, name := range config.Backends {
+		factory, ok := notifiers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown notifier backend: %s", name)
+		}
+		notifier, err := factory(config)
+		if err != nil {
+			return nil, err
+		}
+		multi = append(multi, notifier)
+	}
+	switch len(multi) {
+	case 0:
+		return models.NoopNotifier{}, nil
+	case 1:
+		return multi[0], nil
+	}
+	return multi, nil
+}
+
+// notification renders the title and body templates
+// of a notification
+type notification struct {
+	title *template.Template
+	body  *template.Template
+}
+
+func newNotification(config conf.MessageConfig, title, body string) (notification, error) {
+	if config.Title == "" {
+		config.Title = title
+	}
+	if config.Body == "" {
+		config.Body = body
+	}
+	n := notification{}
+	var err error
+	if n.title, err = template.New("title").Parse(config.Title); err != nil {
+		return n, err
+	}
+	if n.body, err = template.New("body").Parse(config.Body); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func (n notification) render(data models.NotificationData) (string, string, error) {
+	title := new(bytes.Buffer)
+	if err := n.title.Execute(title, data); err != nil {
+		return "", "", err
+	}
+	body := new(bytes.Buffer)
+	if err := n.body.Execute(body, data); err != nil {
+		return "", "", err
+	}
+	return title.String(), body.String(), nil
+}
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// stubNotifier is a backend registered by the tests
+type stubNotifier struct {
+	Icon string
+}
+
+func (stubNotifier) Notify(string, string) error { return nil }
+
+func TestNewNotifier(t *testing.T) {
+	RegisterNotifier("stub", func(config conf.NotifierConfig) (models.Notifier, error) {
+		return stubNotifier{Icon: config.Icon}, nil
+	})
+	RegisterNotifier("broken", func(conf.NotifierConfig) (models.Notifier, error) {
+		return nil, errors.New("broken backend")
+	})
+	t.Cleanup(func() {
+		delete(notifiers, "stub")
+		delete(notifiers, "broken")
+	})
+
+	for _, tc := range []struct {
+		name     string
+		backends []string
+		want     models.Notifier
+		err      string
+	}{
+		{name: "none", want: models.NoopNotifier{}},
+		{name: "one", backends: []string{"bell"}, want: models.BellNotifier{}},
+		{
+			name:     "several",
+			backends: []string{"bell", "command"},
+			want:     models.MultiNotifier{models.BellNotifier{}, models.CommandNotifier{Command: "notify-send"}},
+		},
+		{name: "registered", backends: []string{"stub"}, want: stubNotifier{Icon: "pomo.png"}},
+		{name: "unknown", backends: []string{"bell", "pager"}, err: "unknown notifier backend: pager"},
+		{name: "failing", backends: []string{"broken"}, err: "broken backend"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier, err := NewNotifier(conf.NotifierConfig{Backends: tc.backends, Command: "notify-send", Icon: "pomo.png"})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(notifier, tc.want))
+		})
+	}
+}
+
+func TestNotificationRender(t *testing.T) {
+	data := models.NotificationData{Message: "write docs", Tags: []string{"work"}, Count: 1, NPomodoros: 4}
+	for _, tc := range []struct {
+		name      string
+		config    conf.MessageConfig
+		title     string
+		body      string
+		parseErr  string
+		renderErr string
+	}{
+		{
+			name:  "defaults",
+			title: "Pomo - write docs",
+			body:  "[1/4] It is time to take a break!",
+		},
+		{
+			name:   "configured",
+			config: conf.MessageConfig{Title: "{{index .Tags 0}}", Body: "{{.Count}} of {{.NPomodoros}} done"},
+			title:  "work",
+			body:   "1 of 4 done",
+		},
+		{
+			name:   "configured title only",
+			config: conf.MessageConfig{Title: "Break"},
+			title:  "Break",
+			body:   "[1/4] It is time to take a break!",
+		},
+		{name: "invalid template", config: conf.MessageConfig{Body: "{{.Count"}, parseErr: "unclosed action"},
+		{name: "unknown field", config: conf.MessageConfig{Body: "{{.Project}}"}, renderErr: "can't evaluate field Project"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := newNotification(tc.config, conf.DefaultBreakTitle, conf.DefaultBreakBody)
+			if tc.parseErr != "" {
+				assert.ErrorContains(t, err, tc.parseErr)
+				return
+			}
+			assert.NilError(t, err)
+			title, body, err := n.render(data)
+			if tc.renderErr != "" {
+				assert.ErrorContains(t, err, tc.renderErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(title, tc.title))
+			assert.Check(t, is.Equal(body, tc.body))
+		})
+	}
+}
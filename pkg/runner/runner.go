@@ -1,151 +1,528 @@
 package runner
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/joaorufino/pomo/pkg/conf"
 	"github.com/joaorufino/pomo/pkg/core"
 	"github.com/joaorufino/pomo/pkg/core/models"
 )
 
+// controlInterval is how often the runner asks
+// the server for controls sent by other clients
+const controlInterval = time.Second
+
 func NewRunner(client core.Client, task *models.Task) (core.Runner, error) {
 	return NewTaskRunner(client, task)
 }
 
 type TaskRunner struct {
+	// guards the session, written by run and
+	// read by the controls, the UI and the tests
+	mu           sync.Mutex
 	count        int
 	taskID       int
 	taskMessage  string
+	taskTags     []string
 	nPomodoros   int
 	origDuration time.Duration
 	state        models.State
 	client       core.Client
 	started      time.Time
-	pause        chan bool
-	toggle       chan bool
+	switchTask   chan *models.Task
+	control      chan models.Control
 	notifier     models.Notifier
 	duration     time.Duration
+	// when the pomodoro was paused, the end
+	// of a pomodoro abandoned while paused
+	pausedAt time.Time
+	// how breaks and pomodoros start, as configured
+	// and with the options of the task
+	autoStartConfig autoStart
+	autoStart       autoStart
+	// what happens to a session left waiting
+	pauseIdle idlePolicy
+	breakIdle idlePolicy
+	idle      idleTimer
+	// templates of the notifications
+	breakNotification    notification
+	completeNotification notification
+	// colors of the tags as configured
+	tagColors map[string]string
 }
 
 func (t *TaskRunner) Start() {
-	go t.run()
+	t.startSession()
+}
+
+// startSession runs the session and polls the
+// controls of other clients until it ends
+func (t *TaskRunner) startSession() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t.run()
+	}()
+	go t.pollControls(done)
 }
 
 func (t *TaskRunner) TimeRemaining() time.Duration {
-	return (t.duration - time.Since(t.started)).Truncate(time.Second)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timeRemaining()
+}
+
+// timeRemaining is TimeRemaining with the lock held
+func (t *TaskRunner) timeRemaining() time.Duration {
+	if t.state == models.PAUSED {
+		return t.duration.Truncate(time.Second)
+	}
+	remaining := (t.duration - time.Since(t.started)).Truncate(time.Second)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
 }
 
 func (t *TaskRunner) SetState(state models.State) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.state = state
 }
 
+// locked runs fn with the lock of the session held
+func (t *TaskRunner) locked(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn()
+}
+
+// pending is true while pomodoros of the task are left
+func (t *TaskRunner) pending() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.count < t.nPomodoros
+}
+
 func (t *TaskRunner) run() error {
-	for t.count < t.nPomodoros {
+	for t.pending() {
 		// Create a new pomodoro where we
 		// track the start / end time of
 		// of this session.
 		pomodoro := &models.Pomodoro{}
 		// Start this pomodoro
 		pomodoro.Start = time.Now()
-		// Set state to RUNNIN
-		t.SetState(models.RUNNING)
+		// Set state to RUNNIN and record our started time
+		t.locked(func() {
+			t.state = models.RUNNING
+			t.started = pomodoro.Start
+		})
 		// Create a new timer
 		timer := time.NewTimer(t.duration)
 		// new ticker for periodic status updates
 		ticker := time.NewTicker(5 * time.Second)
-		// Record our started time
-		t.started = pomodoro.Start
 		t.client.UpdateStatus(t.Status())
 	loop:
 		select {
 		case <-timer.C:
-			t.SetState(models.BREAKING)
-			t.count++
-			t.client.UpdateStatus(t.Status())
-		case <-t.toggle:
-			// Catch any toggles when we
-			// are not expecting them
-			goto loop
-		case <-t.pause:
+		case task := <-t.switchTask:
+			// Drop the pomodoro in progress
+			// and start over with the new task
 			timer.Stop()
-			// Record the remaining time of the current pomodoro
-			remaining := t.TimeRemaining()
-			// Change state to PAUSED
-			t.SetState(models.PAUSED)
-			t.client.UpdateStatus(t.Status())
-			// Wait for the user to press [p]
-			<-t.pause
-			// Resume the timer with previous
-			// remaining time
-			timer.Reset(remaining)
-			// Change duration
-			t.started = time.Now()
-			t.duration = remaining
-			// Restore state to RUNNING
-			t.SetState(models.RUNNING)
-			t.client.UpdateStatus(t.Status())
-			goto loop
+			ticker.Stop()
+			t.idle.stop()
+			t.setTask(task)
+			continue
+		case control := <-t.control:
+			switch control.Action {
+			case models.ControlPause:
+				t.togglePause(timer)
+				goto loop
+			case models.ControlSkip:
+				timer.Stop()
+			case models.ControlExtend:
+				t.locked(func() { t.duration = t.timeRemaining() + control.Duration })
+				t.resetTimer(timer)
+				t.client.UpdateStatus(t.Status())
+				goto loop
+			case models.ControlRestart:
+				pomodoro.Start = time.Now()
+				t.locked(func() { t.duration = t.origDuration })
+				t.resetTimer(timer)
+				t.client.UpdateStatus(t.Status())
+				goto loop
+			case models.ControlStop:
+				timer.Stop()
+				ticker.Stop()
+				t.idle.stop()
+				return t.end(pomodoro)
+			default:
+				goto loop
+			}
+		case <-t.idle.C():
+			if !t.idle.fired() {
+				t.remind(fmt.Sprintf("The pomodoro has been paused for %s", t.idle.idle()))
+				goto loop
+			}
+			ticker.Stop()
+			t.remind(fmt.Sprintf("The session was ended after being paused for %s", t.idle.idle()))
+			return t.end(pomodoro)
 		case <-ticker.C:
 			t.client.UpdateStatus(t.Status())
 			goto loop
 		}
+		ticker.Stop()
+		t.idle.stop()
+		t.locked(func() {
+			t.state = models.BREAKING
+			t.count++
+		})
+		t.client.UpdateStatus(t.Status())
 		pomodoro.End = time.Now()
 		err := t.client.CreatePomodoro(t.taskID, *pomodoro)
 		if err != nil {
 			return err
 		}
 		// All pomodoros completed
-		if t.count == t.nPomodoros {
+		if !t.pending() {
 			break
 		}
 
-		t.notifier.Notify("Pomo", "It is time to take a break!")
-		// Reset the duration incase it
-		// was paused.
-		t.duration = t.origDuration
-		// User concludes the break
-		<-t.toggle
+		t.notify(t.breakNotification)
+		// The remaining time counts down timed
+		// breaks and is zero for the others
+		t.locked(func() {
+			t.started = time.Now()
+			t.duration = 0
+			if t.autoStart.timed() {
+				t.duration = t.autoStart.duration
+			}
+		})
+		breaks := newBreakTimers(t.autoStart)
+		if !t.autoStart.timed() {
+			t.idle.start(t.breakIdle)
+		}
+		t.client.UpdateStatus(t.Status())
+		// User concludes the break or
+		// moves on to another task
+	wait:
+		select {
+		case <-breaks.endC():
+			breaks.stop()
+			if t.autoStart.pomodoros {
+				break
+			}
+			t.remind("The break is over, it is time to start the next pomodoro")
+			t.idle.start(t.breakIdle)
+			goto wait
+		case <-breaks.warningC():
+			t.remind(fmt.Sprintf("The next pomodoro starts in %s", t.autoStart.warning))
+			goto wait
+		case task := <-t.switchTask:
+			t.setTask(task)
+		case control := <-t.control:
+			switch control.Action {
+			case models.ControlSkip, models.ControlNext:
+			case models.ControlStop:
+				breaks.stop()
+				t.idle.stop()
+				t.SetState(models.COMPLETE)
+				t.client.UpdateStatus(t.Status())
+				return nil
+			default:
+				// Only pomodoros can be paused,
+				// extended or restarted
+				goto wait
+			}
+		case <-t.idle.C():
+			if !t.idle.fired() {
+				t.remind(fmt.Sprintf("The break has lasted %s, it is time to get back to work!", t.idle.idle()))
+				goto wait
+			}
+			t.remind(fmt.Sprintf("The session was ended after a break of %s", t.idle.idle()))
+			t.SetState(models.COMPLETE)
+			t.client.UpdateStatus(t.Status())
+			return nil
+		}
+		breaks.stop()
+		t.idle.stop()
+		t.locked(func() { t.duration = t.origDuration })
+
+	}
+	t.notify(t.completeNotification)
+	t.SetState(models.COMPLETE)
+	t.client.UpdateStatus(t.Status())
+	return nil
+}
+
+// togglePause pauses the running pomodoro
+// or resumes the paused one
+func (t *TaskRunner) togglePause(timer *time.Timer) {
+	if t.state == models.PAUSED {
+		// Resume the timer with the
+		// remaining time
+		t.idle.stop()
+		t.SetState(models.RUNNING)
+		t.resetTimer(timer)
+	} else {
+		stopTimer(timer)
+		// Record the remaining time of the current pomodoro
+		t.locked(func() {
+			t.duration = t.timeRemaining()
+			t.state = models.PAUSED
+		})
+		t.pausedAt = time.Now()
+		t.idle.start(t.pauseIdle)
+	}
+	t.client.UpdateStatus(t.Status())
+}
 
+// end completes the session keeping the unfinished
+// pomodoro, a paused one ends when it was paused
+func (t *TaskRunner) end(pomodoro *models.Pomodoro) error {
+	pomodoro.End = time.Now()
+	if t.state == models.PAUSED {
+		pomodoro.End = t.pausedAt
+	}
+	if err := t.client.CreatePomodoro(t.taskID, *pomodoro); err != nil {
+		return err
 	}
-	t.notifier.Notify("Pomo", "Pomo session has been completed!")
 	t.SetState(models.COMPLETE)
 	t.client.UpdateStatus(t.Status())
 	return nil
 }
 
+// resetTimer points the timer at the remaining
+// duration, the timer of a paused pomodoro
+// stays stopped until it is resumed
+func (t *TaskRunner) resetTimer(timer *time.Timer) {
+	stopTimer(timer)
+	if t.state != models.PAUSED {
+		t.locked(func() { t.started = time.Now() })
+		timer.Reset(t.duration)
+	}
+}
+
+// stopTimer stops the timer and drains
+// its channel if it already fired
+func stopTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+}
+
+// pollControls applies the controls relayed by the
+// server from other clients until done is closed
+func (t *TaskRunner) pollControls(done <-chan struct{}) {
+	ticker := time.NewTicker(controlInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		control, err := t.client.GetControl()
+		if err != nil || control == nil {
+			continue
+		}
+		t.Apply(*control)
+	}
+}
+
+// notify renders the notification templates
+// with the current task and sends it
+func (t *TaskRunner) notify(n notification) {
+	title, body, err := n.render(models.NotificationData{
+		Message:    t.taskMessage,
+		Tags:       t.taskTags,
+		Count:      t.count,
+		NPomodoros: t.nPomodoros,
+	})
+	if err != nil {
+		title, body = "Pomo", err.Error()
+	}
+	t.notifier.Notify(title, body)
+}
+
+// remind notifies the user of a session left waiting
+func (t *TaskRunner) remind(body string) {
+	t.notifier.Notify("Pomo - "+t.taskMessage, body)
+}
+
+// Toggle starts the next pomodoro at the end of a break
 func (t *TaskRunner) Toggle() {
-	t.toggle <- true
+	t.Apply(models.Control{Action: models.ControlNext})
 }
 
+// Pause pauses the running pomodoro or resumes the paused one
 func (t *TaskRunner) Pause() {
-	t.pause <- true
+	t.Apply(models.Control{Action: models.ControlPause})
+}
+
+// Skip ends the current pomodoro or break early
+func (t *TaskRunner) Skip() {
+	t.Apply(models.Control{Action: models.ControlSkip})
+}
+
+// Extend adds duration to the current pomodoro
+func (t *TaskRunner) Extend(duration time.Duration) {
+	t.Apply(models.Control{Action: models.ControlExtend, Duration: duration})
+}
+
+// Restart starts the current pomodoro over
+func (t *TaskRunner) Restart() {
+	t.Apply(models.Control{Action: models.ControlRestart})
+}
+
+// Stop ends the session, the pomodoro in
+// progress is saved as it is
+func (t *TaskRunner) Stop() {
+	t.Apply(models.Control{Action: models.ControlStop})
+}
+
+// Apply hands control to the session, it is dropped when
+// no session is running or another control is pending
+func (t *TaskRunner) Apply(control models.Control) {
+	if !t.Status().State.Active() {
+		return
+	}
+	select {
+	case t.control <- control:
+	default:
+	}
+}
+
+// SwitchTask abandons the current session and
+// starts a new one with task
+func (t *TaskRunner) SwitchTask(task *models.Task) {
+	if t.Status().State == models.COMPLETE {
+		t.setTask(task)
+		t.startSession()
+		return
+	}
+	t.switchTask <- task
+}
+
+// setTask resets the session to the start of task
+func (t *TaskRunner) setTask(task *models.Task) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.taskID = task.ID
+	t.taskMessage = task.Message
+	t.taskTags = task.Tags
+	t.nPomodoros = task.NPomodoros
+	t.origDuration = task.Duration
+	t.duration = task.Duration
+	t.autoStart = t.autoStartConfig.forTask(task)
+	t.count = 0
+}
+
+// TaskID returns the ID of the task being run
+func (t *TaskRunner) TaskID() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.taskID
+}
+
+// message returns the message of the task being run
+func (t *TaskRunner) message() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.taskMessage
 }
 
 func (t *TaskRunner) Status() *models.Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	return &models.Status{
+		TaskID:     t.taskID,
 		State:      t.state,
 		Count:      t.count,
 		NPomodoros: t.nPomodoros,
-		Remaining:  t.TimeRemaining(),
+		Remaining:  t.timeRemaining(),
 	}
 }
 func (t *TaskRunner) SetStatus(status models.Status) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.state = status.State
 	t.count = status.Count
 	t.nPomodoros = status.NPomodoros
 }
 
 func NewTaskRunner(client core.Client, task *models.Task) (*TaskRunner, error) {
+	config := conf.NotifierConfig{}
+	if err := client.Config().Unmarshal("notifier", &config); err != nil {
+		return nil, err
+	}
+	// keep the desktop notifications of older configurations
+	if !client.Config().Exists("notifier.backends") {
+		config.Backends = []string{"desktop"}
+	}
+	if config.Icon == "" {
+		config.Icon = client.Config().String("icon.path")
+	}
+	notifier, err := NewNotifier(config)
+	if err != nil {
+		return nil, err
+	}
+	runnerConfig := conf.RunnerConfig{}
+	if err := client.Config().Unmarshal("runner", &runnerConfig); err != nil {
+		return nil, err
+	}
+	pauseIdle, err := newIdlePolicy(runnerConfig.Pause)
+	if err != nil {
+		return nil, err
+	}
+	breakIdle, err := newIdlePolicy(runnerConfig.Break)
+	if err != nil {
+		return nil, err
+	}
+	autoStartConfig, err := newAutoStart(runnerConfig.AutoStart)
+	if err != nil {
+		return nil, err
+	}
+	tagColors := map[string]string{}
+	if err := client.Config().Unmarshal("colors", &tagColors); err != nil {
+		return nil, err
+	}
 	tr := &TaskRunner{
-		taskID:       task.ID,
-		taskMessage:  task.Message,
-		nPomodoros:   task.NPomodoros,
-		origDuration: task.Duration,
-		client:       client,
-		state:        models.State(0),
-		pause:        make(chan bool),
-		toggle:       make(chan bool),
-		notifier:     models.NewXnotifier(client.Config().String("icon.path")),
-		duration:     task.Duration,
+		taskID:          task.ID,
+		taskMessage:     task.Message,
+		taskTags:        task.Tags,
+		nPomodoros:      task.NPomodoros,
+		origDuration:    task.Duration,
+		client:          client,
+		state:           models.State(0),
+		switchTask:      make(chan *models.Task),
+		control:         make(chan models.Control, 1),
+		notifier:        notifier,
+		duration:        task.Duration,
+		pauseIdle:       pauseIdle,
+		breakIdle:       breakIdle,
+		autoStartConfig: autoStartConfig,
+		autoStart:       autoStartConfig.forTask(task),
+		tagColors:       tagColors,
+	}
+	if err := tr.setNotifications(config); err != nil {
+		return nil, err
 	}
 	return tr, nil
 }
+
+// setNotifications parses the notification templates
+func (t *TaskRunner) setNotifications(config conf.NotifierConfig) error {
+	var err error
+	t.breakNotification, err = newNotification(config.Break, conf.DefaultBreakTitle, conf.DefaultBreakBody)
+	if err != nil {
+		return err
+	}
+	t.completeNotification, err = newNotification(config.Complete, conf.DefaultCompleteTitle, conf.DefaultCompleteBody)
+	return err
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalCommands maps the signals accepted by
+// a headless session to the command they run
+var signalCommands = map[os.Signal]string{
+	syscall.SIGUSR1: "pause",
+	syscall.SIGUSR2: "skip",
+}
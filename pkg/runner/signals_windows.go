@@ -0,0 +1,6 @@
+package runner
+
+import "os"
+
+// signalCommands is empty, windows has no user signals
+var signalCommands = map[os.Signal]string{}
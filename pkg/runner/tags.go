@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	termui "github.com/gizak/termui/v3"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TagColors maps tags to the color they are shown in
+type TagColors map[string]string
+
+// NewTagColors returns the colors of the configuration,
+// overridden by the colors set with `pomo tag color`
+func NewTagColors(configured map[string]string, tags models.Tags) TagColors {
+	colors := TagColors{}
+	for tag, name := range configured {
+		colors[tag] = name
+	}
+	for tag, name := range tags.Colors() {
+		colors[tag] = name
+	}
+	return colors
+}
+
+// color returns the color name of tag, the configuration
+// keys are lower cased so the tag is looked up lower cased too
+func (c TagColors) color(tag string) string {
+	if name, ok := c[tag]; ok {
+		return name
+	}
+	return c[strings.ToLower(tag)]
+}
+
+// Get returns the terminal color of tag, nil when it has none
+func (c TagColors) Get(tag string) *color.Color {
+	return TermColor(c.color(tag))
+}
+
+// styleTag returns the tag in the markup of the termui widgets,
+// tags are bracketed as the parser can not show a lone bracket
+func (c TagColors) styleTag(tag string) string {
+	name := c.color(tag)
+	fg, ok := uiColor(name)
+	if !ok {
+		return fmt.Sprintf("[[%s]]()", tag)
+	}
+	termui.StyleParserColorMap[name] = fg
+	return fmt.Sprintf("[[%s]](fg:%s)", tag, name)
+}
+
+// uiColor returns the termui color of a color name, true colors
+// are shown in the closest color of the 256 color palette
+func uiColor(name string) (termui.Color, bool) {
+	if fg, ok := termui.StyleParserColorMap[name]; ok && name != "clear" {
+		return fg, true
+	}
+	if models.ValidateColor(name) != nil || name == "" {
+		return 0, false
+	}
+	if r, g, b, ok := hexRGB(name); ok {
+		// the 6x6x6 color cube starts at 16
+		cube := func(v int) int { return (v*5 + 127) / 255 }
+		return termui.Color(16 + 36*cube(r) + 6*cube(g) + cube(b)), true
+	}
+	n, _ := strconv.Atoi(name)
+	return termui.Color(n), true
+}
@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	termui "github.com/gizak/termui/v3"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestTermColor(t *testing.T) {
+	assert.Assert(t, TermColor("red").Equals(color.New(color.FgRed)))
+	assert.Assert(t, TermColor("208").Equals(color.New(38, 5, 208)))
+	assert.Assert(t, TermColor("#ff8700").Equals(color.New(38, 2, 255, 135, 0)))
+	assert.Assert(t, TermColor("teal") == nil)
+	assert.Assert(t, TermColor("") == nil)
+}
+
+func TestTagColors(t *testing.T) {
+	// the configuration keys are lower cased
+	configured := map[string]string{"docs": "blue", "work": "red"}
+	colors := NewTagColors(configured, models.Tags{{Name: "work", Color: "#00ff00"}, {Name: "home"}})
+	assert.Equal(t, colors.color("Docs"), "blue")
+	assert.Equal(t, colors.color("work"), "#00ff00", "the store overrides the configuration")
+	assert.Assert(t, colors.Get("home") == nil)
+
+	assert.Equal(t, colors.styleTag("home"), "[[home]]()")
+	assert.Equal(t, colors.styleTag("work"), "[[work]](fg:#00ff00)")
+	cells := termui.ParseStyles(colors.styleTag("work"), termui.NewStyle(termui.ColorWhite))
+	assert.Equal(t, len(cells), len("[work]"))
+	assert.Equal(t, cells[1].Style.Fg, termui.Color(46))
+}
@@ -2,67 +2,212 @@ package runner
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	termui "github.com/gizak/termui/v3"
 	"github.com/gizak/termui/v3/widgets"
 	"github.com/joaorufino/pomo/pkg/core/models"
 )
 
-func render(wheel *models.Wheel, status *models.Status) *widgets.Paragraph {
-	var text string
-	switch status.State {
-	case models.RUNNING:
-		text = fmt.Sprintf(
-			`[%d/%d] Pomodoros completed
-
-			%s %s remaining
+const helpText = "[enter] next pomodoro  [p] pause  [n] skip  [e] extend  [r] restart  [x] stop  [↑/↓] select task  [s] start selected task  [q] quit"
 
+// extendDuration is added to the pomodoro by [e]
+const extendDuration = 5 * time.Minute
 
-			[q] - quit [p] - pause
-			`,
-			status.Count,
-			status.NPomodoros,
-			wheel,
-			status.Remaining,
-		)
-	case models.BREAKING:
-		text = `It is time to take a break!
+// dashboard holds the widgets of the full screen UI
+type dashboard struct {
+	countdown *widgets.Gauge
+	pomodoros []*widgets.Gauge
+	tasks     *widgets.List
+	week      *widgets.Sparkline
+	weekGroup *widgets.SparklineGroup
+	help      *widgets.Paragraph
+	grid      *termui.Grid
+	// tasks listed, in the order shown
+	today []models.Task
+	// goals whose progress is shown in the header
+	goals []models.Goal
+	// colors of the tags of the listed tasks
+	colors TagColors
+	// note being typed about the last pomodoro, nil when not prompted
+	note *notePrompt
+}
 
-		Once you are ready, press [enter] 
-		to begin the next Pomodoro.
+// notePrompt is the optional note asked
+// for when a pomodoro of the task ends
+type notePrompt struct {
+	taskID int
+	text   string
+}
 
-		[q] - quit [p] - pause
-		`
-	case models.PAUSED:
-		text = `Pomo is suspended.
+// key edits the note, it returns whether the prompt
+// is closed and whether the note is to be saved
+func (p *notePrompt) key(id string) (closed, save bool) {
+	switch id {
+	case "<Enter>":
+		return true, p.text != ""
+	case "<Escape>":
+		return true, false
+	case "<Space>":
+		p.text += " "
+	case "<Backspace>", "<C-<Backspace>>":
+		if _, size := utf8.DecodeLastRuneInString(p.text); size > 0 {
+			p.text = p.text[:len(p.text)-size]
+		}
+	default:
+		if utf8.RuneCountInString(id) == 1 {
+			p.text += id
+		}
+	}
+	return false, false
+}
 
-		Press [p] to continue.
+func (p *notePrompt) String() string {
+	return fmt.Sprintf("Note on the pomodoro: %s_  [enter] save  [esc] skip", p.text)
+}
 
+func newDashboard() *dashboard {
+	d := &dashboard{
+		countdown: widgets.NewGauge(),
+		tasks:     widgets.NewList(),
+		week:      widgets.NewSparkline(),
+		help:      widgets.NewParagraph(),
+		grid:      termui.NewGrid(),
+	}
+	d.tasks.Title = "Today"
+	d.tasks.SelectedRowStyle = termui.NewStyle(termui.ColorWhite, termui.ColorRed)
+	d.tasks.WrapText = false
+	d.week.LineColor = termui.ColorRed
+	d.weekGroup = widgets.NewSparklineGroup(d.week)
+	d.help.Text = helpText
+	d.help.Border = false
+	return d
+}
 
-		[q] - quit [p] - unpause
-		`
+// update sets the widgets from the runner status and the task list
+func (d *dashboard) update(wheel *models.Wheel, status *models.Status, message string, taskID int, tasks models.List, now time.Time) {
+	d.countdown.Title = fmt.Sprintf("Pomo - %s - %s", status.State, message)
+	for _, goal := range d.goals {
+		d.countdown.Title += fmt.Sprintf(" | %s", goal.Progress(tasks, now))
+	}
+	d.countdown.BarColor = termui.ColorRed
+	switch status.State {
+	case models.RUNNING:
+		d.countdown.BarColor = termui.ColorGreen
+		d.countdown.Label = fmt.Sprintf("%s %s remaining", wheel, status.Remaining)
+	case models.BREAKING:
+		d.countdown.BarColor = termui.ColorYellow
+		d.countdown.Label = "It is time to take a break! Press [enter] to begin the next Pomodoro"
+		if status.Remaining > 0 {
+			d.countdown.Label = fmt.Sprintf("Break - %s remaining, press [enter] to begin the next Pomodoro now", status.Remaining)
+		}
+	case models.PAUSED:
+		d.countdown.Label = fmt.Sprintf("Pomo is suspended, press [p] to continue - %s remaining", status.Remaining)
 	case models.COMPLETE:
-		text = `This session has concluded. 
-		
-		Press [q] to exit.
+		d.countdown.Label = "This session has concluded, select another task or press [q] to exit"
+	default:
+		d.countdown.Label = "-"
+	}
 
+	percents := pomodoroPercents(status, d.duration(taskID, tasks))
+	if len(d.pomodoros) != len(percents) {
+		d.pomodoros = make([]*widgets.Gauge, len(percents))
+		for i := range d.pomodoros {
+			d.pomodoros[i] = widgets.NewGauge()
+			d.pomodoros[i].Title = fmt.Sprintf("#%d", i+1)
+		}
+	}
+	d.countdown.Percent = 0
+	for i, percent := range percents {
+		d.pomodoros[i].Percent = percent
+		d.pomodoros[i].BarColor = termui.ColorGreen
+		if i == status.Count && status.State != models.COMPLETE {
+			d.countdown.Percent = percent
+			d.pomodoros[i].BarColor = termui.ColorRed
+		}
+	}
 
-		
+	selected := d.selected()
+	d.today = todayTasks(tasks, now)
+	d.tasks.Rows = make([]string, len(d.today))
+	for i, task := range d.today {
+		marker := " "
+		if task.ID == taskID {
+			marker = "▶"
+		}
+		d.tasks.Rows[i] = fmt.Sprintf("%s %d: [%d/%d] %s", marker, task.ID, len(task.Pomodoros), task.NPomodoros, task.Message)
+		if len(task.Tags) > 0 {
+			tags := make([]string, len(task.Tags))
+			for j, tag := range task.Tags {
+				tags[j] = d.colors.styleTag(tag)
+			}
+			d.tasks.Rows[i] += " " + strings.Join(tags, " ")
+		}
+		if selected != nil && task.ID == selected.ID {
+			d.tasks.SelectedRow = i
+		}
+	}
+	if d.tasks.SelectedRow >= len(d.today) {
+		d.tasks.SelectedRow = 0
+	}
 
+	counts := weekCounts(tasks, now)
+	total := 0
+	d.week.Data = make([]float64, len(counts))
+	for i, count := range counts {
+		d.week.Data[i] = float64(count)
+		total += count
+	}
+	d.weekGroup.Title = fmt.Sprintf("Week - %d pomodoros", total)
 
-		[q] - quit
-		`
+	d.help.Text = helpText
+	if d.note != nil {
+		d.help.Text = d.note.String()
 	}
-	par := widgets.NewParagraph()
-	par.Text = text
-	par.SetRect(0, 10, 20, 20)
-	par.Title = fmt.Sprintf("Pomo - %s", status.State)
-	par.BorderStyle.Fg = termui.ColorRed
-	if status.State == models.RUNNING {
-		par.BorderStyle.Fg = termui.ColorGreen
+}
+
+// duration returns the pomodoro duration of the running task
+func (d *dashboard) duration(taskID int, tasks models.List) time.Duration {
+	for _, task := range tasks {
+		if task.ID == taskID {
+			return task.Duration
+		}
 	}
-	return par
+	return 0
+}
+
+// selected returns the task under the cursor
+func (d *dashboard) selected() *models.Task {
+	if d.tasks.SelectedRow < 0 || d.tasks.SelectedRow >= len(d.today) {
+		return nil
+	}
+	return &d.today[d.tasks.SelectedRow]
+}
+
+// layout sizes the grid to the terminal
+func (d *dashboard) layout(width, height int) {
+	gauges := []interface{}{newBlk()}
+	if len(d.pomodoros) > 0 {
+		gauges = make([]interface{}, len(d.pomodoros))
+		for i, gauge := range d.pomodoros {
+			gauges[i] = termui.NewCol(1.0/float64(len(d.pomodoros)), gauge)
+		}
+	}
+	d.grid = termui.NewGrid()
+	d.grid.SetRect(0, 0, width, height)
+	d.grid.Set(
+		termui.NewRow(0.9,
+			termui.NewCol(0.6,
+				termui.NewRow(0.4, d.countdown),
+				termui.NewRow(0.2, gauges...),
+				termui.NewRow(0.4, d.weekGroup),
+			),
+			termui.NewCol(0.4, d.tasks),
+		),
+		termui.NewRow(0.1, d.help),
+	)
 }
 
 func newBlk() *termui.Block {
@@ -71,24 +216,63 @@ func newBlk() *termui.Block {
 	return blk
 }
 
-func centered(part *widgets.Paragraph) *termui.Grid {
-	grid := termui.NewGrid()
-	termWidth, termHeight := termui.TerminalDimensions()
-	grid.SetRect(0, 0, termWidth, termHeight)
-	grid.Set(
-		termui.NewRow(1.0/2,
-			termui.NewCol(1.0/2, newBlk()),
-		),
-		termui.NewRow(1.0/4,
-			termui.NewCol(1.0/3, newBlk()),
-			termui.NewCol(1.0/3, part),
-			termui.NewCol(1.0/3, newBlk()),
-		),
-		termui.NewRow(1.0/4,
-			termui.NewCol(1.0/4, newBlk()),
-		),
-	)
-	return grid
+// pomodoroPercents returns the progress of each
+// pomodoro of the session
+func pomodoroPercents(status *models.Status, duration time.Duration) []int {
+	percents := make([]int, status.NPomodoros)
+	for i := range percents {
+		switch {
+		case i < status.Count:
+			percents[i] = 100
+		case i == status.Count && duration > 0 && (status.State == models.RUNNING || status.State == models.PAUSED):
+			percents[i] = int(100 * (duration - status.Remaining) / duration)
+			if percents[i] < 0 {
+				percents[i] = 0
+			}
+		}
+	}
+	return percents
+}
+
+// todayTasks returns the unfinished tasks and
+// the ones worked on today
+func todayTasks(tasks models.List, now time.Time) models.List {
+	year, month, day := now.Date()
+	today := models.List{}
+	for _, task := range tasks {
+		if len(task.Pomodoros) < task.NPomodoros {
+			today = append(today, task)
+			continue
+		}
+		for _, pomodoro := range task.Pomodoros {
+			if y, m, d := pomodoro.Start.Date(); y == year && m == month && d == day {
+				today = append(today, task)
+				break
+			}
+		}
+	}
+	return today
+}
+
+// weekCounts returns the number of pomodoros started
+// on each of the last seven days, oldest first
+func weekCounts(tasks models.List, now time.Time) []int {
+	year, month, day := now.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	first := midnight.AddDate(0, 0, -6)
+	counts := make([]int, 7)
+	for _, task := range tasks {
+		for _, pomodoro := range task.Pomodoros {
+			start := pomodoro.Start.In(now.Location())
+			if start.Before(first) || !start.Before(midnight.AddDate(0, 0, 1)) {
+				continue
+			}
+			y, m, d := start.Date()
+			days := int(time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Sub(first).Hours()+12) / 24
+			counts[days]++
+		}
+	}
+	return counts
 }
 
 func (runner *TaskRunner) StartUI() {
@@ -100,26 +284,88 @@ func (runner *TaskRunner) StartUI() {
 
 	defer termui.Close()
 
-	termui.Render(centered(render(&wheel, runner.Status())))
+	d := newDashboard()
+	var tasks models.List
+	refresh := func() {
+		list, err := runner.client.GetTaskList()
+		if err == nil {
+			tasks = *list
+		}
+		if goals, err := runner.client.GetGoals(); err == nil {
+			d.goals = goals
+		}
+		if tags, err := runner.client.GetTags(); err == nil {
+			d.colors = NewTagColors(runner.tagColors, tags)
+		}
+	}
+	draw := func() {
+		d.update(&wheel, runner.Status(), runner.message(), runner.TaskID(), tasks, time.Now())
+		d.layout(termui.TerminalDimensions())
+		termui.Render(d.grid)
+	}
+	refresh()
+	draw()
+
+	last := *runner.Status()
 	uiEvents := termui.PollEvents()
 	ticker := time.NewTicker(time.Second).C
 	for {
 		select {
 		case e := <-uiEvents:
+			if d.note != nil {
+				if closed, save := d.note.key(e.ID); closed {
+					if save {
+						note := models.Note{Text: d.note.text, Pomodoro: models.NoteLastPomodoro}
+						if err := runner.client.AddNote(d.note.taskID, note); err == nil {
+							refresh()
+						}
+					}
+					d.note = nil
+				}
+				draw()
+				continue
+			}
 			switch e.ID {
 			case "<Enter>":
-				runner.Toggle()
-				termui.Render(centered(render(&wheel, runner.Status())))
+				if runner.Status().State == models.BREAKING {
+					runner.Toggle()
+				}
 			case "q", "<C-c>":
 				return
 			case "p":
-				runner.Pause()
-				termui.Render(centered(render(&wheel, runner.Status())))
-			case "<Resize>":
-				termui.Render(centered(render(&wheel, runner.Status())))
+				if state := runner.Status().State; state == models.RUNNING || state == models.PAUSED {
+					runner.Pause()
+				}
+			case "n":
+				runner.Skip()
+			case "e":
+				runner.Extend(extendDuration)
+			case "r":
+				runner.Restart()
+			case "x":
+				runner.Stop()
+			case "<Down>", "j":
+				d.tasks.ScrollDown()
+			case "<Up>", "k":
+				d.tasks.ScrollUp()
+			case "s":
+				if task := d.selected(); task != nil {
+					runner.SwitchTask(task)
+				}
 			}
+			draw()
 		case <-ticker:
-			termui.Render(centered(render(&wheel, runner.Status())))
+			// reload the tasks once a pomodoro is saved
+			status := *runner.Status()
+			if status.State != last.State || status.Count != last.Count {
+				refresh()
+			}
+			// ask for a note once a pomodoro ends
+			if status.Count > last.Count && d.note == nil {
+				d.note = &notePrompt{taskID: runner.TaskID()}
+			}
+			last = status
+			draw()
 		}
 	}
 }
@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPomodoroPercents(t *testing.T) {
+	status := &models.Status{
+		State:      models.RUNNING,
+		Count:      1,
+		NPomodoros: 3,
+		Remaining:  15 * time.Minute,
+	}
+	assert.DeepEqual(t, pomodoroPercents(status, 20*time.Minute), []int{100, 25, 0})
+
+	status.State = models.BREAKING
+	status.Count = 2
+	assert.DeepEqual(t, pomodoroPercents(status, 20*time.Minute), []int{100, 100, 0})
+}
+
+func TestTodayTasks(t *testing.T) {
+	now := time.Date(2021, 1, 16, 15, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	tasks := models.List{
+		{ID: 1, NPomodoros: 1, Pomodoros: []*models.Pomodoro{{Start: yesterday, End: yesterday}}},
+		{ID: 2, NPomodoros: 1, Pomodoros: []*models.Pomodoro{{Start: now, End: now}}},
+		{ID: 3, NPomodoros: 2, Pomodoros: []*models.Pomodoro{{Start: yesterday, End: yesterday}}},
+	}
+	today := todayTasks(tasks, now)
+	assert.Equal(t, len(today), 2)
+	assert.Equal(t, today[0].ID, 2)
+	assert.Equal(t, today[1].ID, 3)
+}
+
+func TestWeekCounts(t *testing.T) {
+	now := time.Date(2021, 1, 16, 15, 0, 0, 0, time.Local)
+	pomodoro := func(days int) *models.Pomodoro {
+		start := now.AddDate(0, 0, -days)
+		return &models.Pomodoro{Start: start, End: start.Add(25 * time.Minute)}
+	}
+	tasks := models.List{
+		{Pomodoros: []*models.Pomodoro{pomodoro(0), pomodoro(0), pomodoro(1)}},
+		{Pomodoros: []*models.Pomodoro{pomodoro(6), pomodoro(7)}},
+	}
+	assert.DeepEqual(t, weekCounts(tasks, now), []int{1, 0, 0, 0, 0, 1, 2})
+}
+
+func TestDashboardUpdate(t *testing.T) {
+	now := time.Now()
+	tasks := models.List{
+		{ID: 1, Message: "first", NPomodoros: 2, Duration: time.Minute},
+		{ID: 2, Message: "second", NPomodoros: 1, Duration: time.Minute, Tags: []string{"docs", "work"}},
+	}
+	d := newDashboard()
+	d.colors = TagColors{"docs": "red"}
+	wheel := models.Wheel(0)
+	status := &models.Status{State: models.RUNNING, NPomodoros: 2, Remaining: 30 * time.Second}
+	d.update(&wheel, status, "first", 1, tasks, now)
+	assert.Equal(t, len(d.pomodoros), 2)
+	assert.Equal(t, d.countdown.Percent, 50)
+	assert.Equal(t, len(d.tasks.Rows), 2)
+	assert.Equal(t, d.tasks.Rows[1], "  2: [0/1] second [[docs]](fg:red) [[work]]()")
+
+	d.tasks.ScrollDown()
+	assert.Equal(t, d.selected().ID, 2)
+	// the selection follows the task when the list is reloaded
+	d.update(&wheel, status, "first", 1, tasks[1:], now)
+	assert.Equal(t, d.selected().ID, 2)
+
+	d.goals = []models.Goal{{Period: models.GoalDaily, Unit: models.GoalPomodoros, Target: 4}}
+	d.update(&wheel, status, "first", 1, tasks, now)
+	assert.Equal(t, d.countdown.Title, "Pomo - RUNNING - first | day: 0/4 pomodoros")
+
+	d.layout(80, 24)
+}
+
+func TestNotePrompt(t *testing.T) {
+	prompt := &notePrompt{taskID: 1}
+	for _, id := range []string{"d", "o", "n", "e", "<Space>", "é", "x", "<Backspace>", "<Up>"} {
+		closed, _ := prompt.key(id)
+		assert.Assert(t, !closed)
+	}
+	assert.Equal(t, prompt.text, "done é")
+
+	closed, save := prompt.key("<Enter>")
+	assert.Assert(t, closed && save)
+	closed, save = prompt.key("<Escape>")
+	assert.Assert(t, closed && !save)
+	closed, save = (&notePrompt{}).key("<Enter>")
+	assert.Assert(t, closed && !save, "an empty note is not saved")
+}
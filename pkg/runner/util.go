@@ -2,6 +2,8 @@ package runner
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -9,7 +11,7 @@ import (
 	"github.com/joaorufino/pomo/pkg/core/models"
 )
 
-func SummarizeTasks(datetimeformat string, tasks models.List) {
+func SummarizeTasks(datetimeformat string, colors TagColors, tasks models.List) {
 	for _, task := range tasks {
 		var start string
 		if len(task.Pomodoros) > 0 {
@@ -19,28 +21,62 @@ func SummarizeTasks(datetimeformat string, tasks models.List) {
 		printPomodoros(&task)
 		// Tags
 		if len(task.Tags) > 0 {
-			printTags(&task)
+			printTags(&task, colors)
 		}
 		fmt.Printf(" - %s", task.Message)
 		fmt.Printf("\n")
 	}
 }
 
-func printTags(task *models.Task) {
+// TermColor returns the terminal color named by one of models.Colors,
+// a 256 color number or a #rrggbb true color, nil for the others
+func TermColor(name string) *color.Color {
+	attributes := map[string]color.Attribute{
+		"black":   color.FgBlack,
+		"red":     color.FgRed,
+		"green":   color.FgGreen,
+		"yellow":  color.FgYellow,
+		"blue":    color.FgBlue,
+		"magenta": color.FgMagenta,
+		"cyan":    color.FgCyan,
+		"white":   color.FgWhite,
+	}
+	if attribute, ok := attributes[name]; ok {
+		return color.New(attribute)
+	}
+	if models.ValidateColor(name) != nil || name == "" {
+		return nil
+	}
+	// 38;5;n and 38;2;r;g;b select the extended foreground colors
+	if r, g, b, ok := hexRGB(name); ok {
+		return color.New(38, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b))
+	}
+	n, _ := strconv.Atoi(name)
+	return color.New(38, 5, color.Attribute(n))
+}
+
+// hexRGB returns the components of a #rrggbb color
+func hexRGB(name string) (r, g, b int, ok bool) {
+	if !strings.HasPrefix(name, "#") {
+		return 0, 0, 0, false
+	}
+	rgb, err := strconv.ParseUint(name[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(rgb >> 16), int(rgb >> 8 & 0xff), int(rgb & 0xff), true
+}
+
+func printTags(task *models.Task, colors TagColors) {
 	fmt.Printf(" [")
 	for i, tag := range task.Tags {
-		if i > 0 && i != len(task.Tags) {
+		if i > 0 {
 			fmt.Printf(" ")
-			//// user specified color mapping exists
-			//if config.Colors != nil {
-			//if color := config.Colors.Get(tag); color != nil {
-			//color.Printf("%s", tag)
-			//} else {
-			//// no color mapping for tag
-			//fmt.Printf("%s", tag)
-			//}
+		}
+		if c := colors.Get(tag); c != nil {
+			c.Printf("%s", tag)
 		} else {
-			// no color mapping
+			// no color mapping for tag
 			fmt.Printf("%s", tag)
 		}
 	}
@@ -76,14 +112,17 @@ func printPomodoros(task *models.Task) {
 }
 
 func OutputStatus(status models.Status) {
+	fmt.Println(FormatStatus(status))
+}
+
+// FormatStatus returns the one line summary of a status
+func FormatStatus(status models.Status) string {
 	state := "?"
 	if status.State >= models.RUNNING {
 		state = string(status.State.String()[0])
 	}
 	if status.State == models.RUNNING {
-		fmt.Printf("%s [%d/%d] %s", state, status.Count, status.NPomodoros, status.Remaining)
-	} else {
-		fmt.Printf("%s [%d/%d] -", state, status.Count, status.NPomodoros)
+		return fmt.Sprintf("%s [%d/%d] %s", state, status.Count, status.NPomodoros, status.Remaining)
 	}
-	fmt.Println()
+	return fmt.Sprintf("%s [%d/%d] -", state, status.Count, status.NPomodoros)
 }
@@ -0,0 +1,185 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"go.uber.org/zap"
+)
+
+// fileLayout names the backups after the time they were made
+const fileLayout = "pomo-20060102-150405.db"
+
+// checkInterval is the longest time between two
+// checks for a due backup
+const checkInterval = 10 * time.Minute
+
+// File is a backup of the database
+type File struct {
+	Path string
+	Time time.Time
+}
+
+// List returns the backups found in dir, oldest first
+func List(dir string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []File{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := []File{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		made, err := time.ParseInLocation(fileLayout, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		files = append(files, File{Path: filepath.Join(dir, entry.Name()), Time: made})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Time.Before(files[j].Time)
+	})
+	return files, nil
+}
+
+// Create backs up the database in dir and returns the
+// path of the backup, named after now
+func Create(ctx context.Context, store core.Store, dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, now.Format(fileLayout))
+	return path, store.Backup(ctx, path)
+}
+
+// Rotate removes the oldest backups of dir until
+// keep are left and returns the removed paths
+func Rotate(dir string, keep int) ([]string, error) {
+	removed := []string{}
+	if keep <= 0 {
+		return removed, nil
+	}
+	files, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	for len(files) > keep {
+		if err := os.Remove(files[0].Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, files[0].Path)
+		files = files[1:]
+	}
+	return removed, nil
+}
+
+// Scheduler backs up the database every
+// interval and rotates the backups
+type Scheduler struct {
+	store    core.Store
+	dir      string
+	interval time.Duration
+	keep     int
+	logger   *zap.SugaredLogger
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a scheduler backing up the database
+// every configured interval, an interval that is
+// empty or not positive disables the backups
+func New(store core.Store, config conf.BackupConfig) *Scheduler {
+	interval, err := time.ParseDuration(config.Interval)
+	if err != nil {
+		interval = 0
+	}
+	return &Scheduler{
+		store:    store,
+		dir:      config.Dir,
+		interval: interval,
+		keep:     config.Keep,
+		logger:   zap.S().With("package", "backup"),
+	}
+}
+
+// Start runs the backup loop in the background
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil || s.interval <= 0 || s.dir == "" {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.loop(s.stop, s.done)
+}
+
+// Stop halts the backup loop
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
+
+func (s *Scheduler) loop(stop, done chan struct{}) {
+	defer close(done)
+	every := s.interval
+	if every > checkInterval {
+		every = checkInterval
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Run(context.Background(), time.Now()); err != nil {
+			s.logger.Errorw("Could not back up the database", "error", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run backs up the database when the last backup is
+// an interval older than now, it returns the path of
+// the new backup or an empty string when none was due
+func (s *Scheduler) Run(ctx context.Context, now time.Time) (string, error) {
+	if s.interval <= 0 {
+		return "", nil
+	}
+	files, err := List(s.dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) > 0 && now.Sub(files[len(files)-1].Time) < s.interval {
+		return "", nil
+	}
+	path, err := Create(ctx, s.store, s.dir, now)
+	if err != nil {
+		return "", err
+	}
+	s.logger.Debugw("Backed up the database", "path", path)
+	removed, err := Rotate(s.dir, s.keep)
+	if len(removed) > 0 {
+		s.logger.Debugw("Removed old backups", "paths", removed)
+	}
+	return path, err
+}
@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRun(t *testing.T) {
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	scheduler := New(store, conf.BackupConfig{Dir: dir, Interval: "1h", Keep: 2})
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+
+	created, err := scheduler.Run(ctx, now)
+	assert.NilError(t, err)
+	assert.Equal(t, created, path.Join(dir, "pomo-20240301-090000.db"))
+	created, err = scheduler.Run(ctx, now.Add(30*time.Minute))
+	assert.NilError(t, err)
+	assert.Equal(t, created, "", "not due yet")
+	for i := 1; i <= 3; i++ {
+		created, err = scheduler.Run(ctx, now.Add(time.Duration(i)*time.Hour))
+		assert.NilError(t, err)
+		assert.Check(t, created != "")
+	}
+
+	files, err := List(dir)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(files, 2), "rotated to the kept backups")
+	assert.Check(t, files[0].Time.Equal(now.Add(2*time.Hour)))
+	assert.Check(t, files[1].Time.Equal(now.Add(3*time.Hour)))
+
+	created, err = New(store, conf.BackupConfig{Dir: dir}).Run(ctx, now.AddDate(1, 0, 0))
+	assert.NilError(t, err)
+	assert.Equal(t, created, "", "no interval disables the backups")
+}
+
+func TestRestore(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "pomo.db")
+	store, err := sqlite.NewStore(dbPath)
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	_, err = store.TaskSave(ctx, &models.Task{Message: "kept", NPomodoros: 1, Duration: time.Minute})
+	assert.NilError(t, err)
+	backup, err := Create(ctx, store, t.TempDir(), time.Now())
+	assert.NilError(t, err)
+	assert.Check(t, store.Backup(ctx, backup) != nil, "backups are not overwritten")
+
+	lostID, err := store.TaskSave(ctx, &models.Task{Message: "lost", NPomodoros: 1, Duration: time.Minute})
+	assert.NilError(t, err)
+	start := time.Now().Add(-time.Hour)
+	assert.NilError(t, store.PomodoroSave(ctx, lostID, &models.Pomodoro{Start: start, End: start.Add(time.Minute)}))
+	// only a damaged database has pomodoros without their task
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NilError(t, err)
+	_, err = db.Exec("DELETE FROM task WHERE rowid = $1", lostID)
+	assert.NilError(t, err)
+	assert.NilError(t, db.Close())
+	check, err := store.Check(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(check.Integrity, 0))
+	assert.Assert(t, is.Len(check.OrphanedPomodoros, 1))
+	assert.Equal(t, check.OrphanedPomodoros[0].TaskID, lostID)
+	assert.Check(t, !check.OK())
+
+	assert.Check(t, store.Restore(ctx, path.Join(t.TempDir(), "missing.db")) != nil)
+	assert.NilError(t, store.Restore(ctx, backup))
+	tasks, err := store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(tasks, 1))
+	assert.Equal(t, tasks[0].Message, "kept")
+	assert.NilError(t, store.Vacuum(ctx))
+	check, err = store.Check(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, check.OK())
+}
+
+func TestVacuumKeepsIDs(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "pomo.db")
+	// the tables of the older databases have no id column
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NilError(t, err)
+	_, err = db.Exec(`CREATE TABLE task (message TEXT, pomodoros INTEGER, duration TEXT, tags TEXT);
+		CREATE TABLE pomodoro (task_id INTEGER, start DATETTIME, end DATETTIME)`)
+	assert.NilError(t, err)
+	for i := 1; i <= 3; i++ {
+		_, err = db.Exec("INSERT INTO task (message,pomodoros,duration,tags) VALUES ($1,1,'1m0s','')", fmt.Sprintf("old %d", i))
+		assert.NilError(t, err)
+	}
+	_, err = db.Exec("DELETE FROM task WHERE rowid = 1")
+	assert.NilError(t, err)
+	assert.NilError(t, db.Close())
+
+	store, err := sqlite.NewStore(dbPath)
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, taskID := range []int{2, 3} {
+		assert.NilError(t, store.PomodoroSave(ctx, taskID, &models.Pomodoro{Start: start, End: start.Add(time.Minute)}))
+	}
+	newID, err := store.TaskSave(ctx, &models.Task{Message: "new", NPomodoros: 1, Duration: time.Minute})
+	assert.NilError(t, err)
+	assert.Equal(t, newID, 4)
+	assert.NilError(t, store.PomodoroSave(ctx, newID, &models.Pomodoro{Start: start, End: start.Add(time.Minute)}))
+	// the purge leaves a gap in the IDs
+	assert.NilError(t, store.TaskDeleteByID(ctx, 2))
+	_, err = store.TrashPurge(ctx, time.Now().Add(time.Minute))
+	assert.NilError(t, err)
+
+	assert.NilError(t, store.Vacuum(ctx))
+	tasks, err := store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(tasks, 2))
+	for _, task := range tasks {
+		pomodoros, err := store.PomodoroGetByTaskID(ctx, task.ID)
+		assert.NilError(t, err)
+		assert.Assert(t, is.Len(pomodoros, 1))
+		assert.Check(t, is.Equal(pomodoros[0].TaskID, task.ID))
+	}
+	assert.Check(t, is.Equal(tasks[0].ID, 3))
+	assert.Check(t, is.Equal(tasks[0].Message, "old 3"))
+	assert.Check(t, is.Equal(tasks[1].ID, 4))
+	assert.Check(t, is.Equal(tasks[1].Message, "new"))
+}
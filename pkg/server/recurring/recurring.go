@@ -0,0 +1,110 @@
+package recurring
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/webhook"
+	"go.uber.org/zap"
+)
+
+// Scheduler creates the tasks of the recurring templates
+// on the days they are due, while the server runs
+type Scheduler struct {
+	store    core.Store
+	webhooks *webhook.Dispatcher
+	interval time.Duration
+	logger   *zap.SugaredLogger
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a scheduler checking the templates every
+// configured interval, the created tasks are published
+// to the webhooks
+func New(store core.Store, webhooks *webhook.Dispatcher, config conf.RecurringConfig) *Scheduler {
+	interval, err := time.ParseDuration(config.Interval)
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{
+		store:    store,
+		webhooks: webhooks,
+		interval: interval,
+		logger:   zap.S().With("package", "recurring"),
+	}
+}
+
+// Start runs the scheduling loop in the background
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.loop(s.stop, s.done)
+}
+
+// Stop halts the scheduling loop
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
+
+func (s *Scheduler) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Materialise(context.Background(), time.Now()); err != nil {
+			s.logger.Errorw("Could not create the recurring tasks", "error", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Materialise creates the task of every template due
+// at now and returns the IDs of the created tasks
+func (s *Scheduler) Materialise(ctx context.Context, now time.Time) ([]int, error) {
+	templates, err := s.store.TemplateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	created := []int{}
+	for _, template := range templates {
+		if !template.Due(now) {
+			continue
+		}
+		task := template.Task()
+		// saved with the time of its creation so that neither
+		// a failure nor a failing webhook creates the task twice
+		task.ID, err = s.store.TemplateMaterialise(ctx, template.ID, task, now)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, task.ID)
+		if err := s.webhooks.Publish(ctx, models.EventTaskCreated, task); err != nil {
+			s.logger.Errorf("Could not publish %s event: %s", models.EventTaskCreated, err)
+		}
+		s.logger.Debugw("Created recurring task", "template", template.Name, "task", task.ID)
+	}
+	return created, nil
+}
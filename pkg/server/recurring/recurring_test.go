@@ -0,0 +1,82 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestMaterialise(t *testing.T) {
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	templates := models.Templates{
+		{Name: "standup", Message: "Stand-up", NPomodoros: 1, Duration: 15 * time.Minute, Tags: []string{"team"}, Recurrence: models.RecurWeekdays},
+		{Name: "review", Message: "Code review", NPomodoros: 2, Duration: 25 * time.Minute, Recurrence: models.RecurWeekly, Weekday: time.Friday},
+		{Name: "inbox", Message: "Inbox zero", NPomodoros: 1, Duration: 25 * time.Minute},
+	}
+	for _, template := range templates {
+		template := template
+		_, err := store.TemplateSave(ctx, &template)
+		assert.NilError(t, err)
+	}
+
+	s := New(store, nil, conf.RecurringConfig{})
+	// a Thursday
+	thursday := time.Date(2021, 1, 14, 9, 0, 0, 0, time.Local)
+	created, err := s.Materialise(ctx, thursday)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(created, 1))
+	// once a day
+	created, err = s.Materialise(ctx, thursday.Add(time.Hour))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(created, 0))
+
+	created, err = s.Materialise(ctx, thursday.AddDate(0, 0, 1))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(created, 2))
+
+	tasks, err := store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(tasks, 3))
+	assert.Check(t, is.Equal(tasks[0].Message, "Stand-up"))
+	assert.Check(t, is.DeepEqual(tasks[0].Tags, []string{"team"}))
+	assert.Check(t, is.Equal(tasks[0].Duration, 15*time.Minute))
+}
+
+func TestMaterialiseFailureCreatesNoTask(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "pomo.db")
+	store, err := sqlite.NewStore(dbPath)
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	template := &models.Template{Name: "inbox", Message: "Inbox zero", NPomodoros: 1, Duration: 25 * time.Minute, Recurrence: models.RecurDaily}
+	_, err = store.TemplateSave(ctx, template)
+	assert.NilError(t, err)
+	// recording the creation fails after the task was inserted
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NilError(t, err)
+	_, err = db.Exec("CREATE TRIGGER fail BEFORE UPDATE ON template BEGIN SELECT RAISE(ABORT, 'failed'); END")
+	assert.NilError(t, err)
+	assert.NilError(t, db.Close())
+
+	s := New(store, nil, conf.RecurringConfig{})
+	_, err = s.Materialise(ctx, time.Now())
+	assert.Assert(t, err != nil)
+	tasks, err := store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(tasks, 0))
+}
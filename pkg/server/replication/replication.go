@@ -0,0 +1,75 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// Path is where a pomo rest server exports
+// its batch and merges the batch of another
+const Path = "/sync"
+
+// httpClient bounds the time spent on the remote server
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// Sync exchanges the tasks, pomodoros and notes of the store with the
+// pomo rest server at remote, each side merges the batch of
+// the other so that both end up with the newest changes
+func Sync(ctx context.Context, store core.Store, remote string) (*models.SyncReport, error) {
+	url := strings.TrimSuffix(remote, "/") + Path
+	outgoing, err := store.SyncExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	incoming := &models.SyncBatch{}
+	if err := request(ctx, http.MethodGet, url, nil, incoming); err != nil {
+		return nil, err
+	}
+	report := &models.SyncReport{}
+	local, err := store.SyncMerge(ctx, incoming)
+	if err != nil {
+		return nil, err
+	}
+	report.Local = *local
+	if err := request(ctx, http.MethodPost, url, outgoing, &report.Remote); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// request sends body as JSON to the remote server and decodes
+// its answer into response, a remote server that cannot be
+// reached or fails is a models.ErrorTypeUnavailable error
+func request(ctx context.Context, method, url string, body, response interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return &models.Error{Type: models.ErrorTypeUnavailable, Err: err}
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return models.NewError(models.ErrorTypeUnavailable, "%s %s: status code %d", method, url, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(response)
+}
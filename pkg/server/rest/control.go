@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// controlQueueSize is the number of controls kept
+// until the client running the session picks them up
+const controlQueueSize = 8
+
+// ControlSend queues a control for the running session
+func (s *RestServer) ControlSend() http.HandlerFunc {
+
+	// swagger:operation POST /status/control ControlSend
+	//
+	// Control the session
+	//
+	// Queues a control for the client running the session
+	//
+	// ---
+	// parameters:
+	// - name: control
+	//   in: body
+	//   description: Control to send
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Control"
+	// responses:
+	//   '202':
+	//     description: Control Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Control"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var control = new(models.Control)
+		if err := DecodeJSON(r.Body, control); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if err := control.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if !s.currentStatus().State.Active() {
+			RenderErrInvalidRequest(w, errors.New("no session is running"))
+			return
+		}
+		select {
+		case s.controls <- *control:
+		default:
+			RenderErrInvalidRequest(w, errors.New("too many pending controls"))
+			return
+		}
+
+		RenderJSON(w, http.StatusAccepted, control)
+	}
+
+}
+
+// dropControls empties the queue once the session has ended,
+// the controls sent too late never reach the next session
+func (s *RestServer) dropControls() {
+	for {
+		select {
+		case <-s.controls:
+		default:
+			return
+		}
+	}
+}
+
+// ControlNext hands the oldest queued control
+// to the client running the session
+func (s *RestServer) ControlNext() http.HandlerFunc {
+
+	// swagger:operation DELETE /status/control ControlNext
+	//
+	// Take the next control
+	//
+	// Removes the oldest queued control and returns it
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Control Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Control"
+	//   '204':
+	//     description: No control is queued
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case control := <-s.controls:
+			RenderJSON(w, http.StatusOK, control)
+		default:
+			RenderNoContent(w)
+		}
+	}
+
+}
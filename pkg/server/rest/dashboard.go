@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"embed"
+	"io"
+	"io/fs"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// Dashboard serves the files of the embedded web dashboard
+func (s *RestServer) Dashboard() http.HandlerFunc {
+	// swagger:operation GET /dashboard/{file} Dashboard
+	//
+	// Web dashboard
+	//
+	// Serves the files of the embedded web dashboard
+	//
+	// ---
+	// produces:
+	// - text/html
+	// parameters:
+	// - name: file
+	//   in: path
+	//   description: File to fetch
+	//   type: string
+	//   required: true
+	// responses:
+	//   '200':
+	//     description: Dashboard file
+	//   '404':
+	//     description: Not Found
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	files, _ := fs.Sub(webFiles, "web")
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "file")
+		file, err := files.Open(name)
+		if err != nil {
+			RenderErrNotFound(w)
+			return
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			RenderErrNotFound(w)
+			return
+		}
+		http.ServeContent(w, r, name, info.ModTime(), file.(io.ReadSeeker))
+	}
+}
+
+// DashboardRedirect sends the browser to the dashboard
+func (s *RestServer) DashboardRedirect() http.HandlerFunc {
+	// swagger:operation GET / DashboardRedirect
+	//
+	// Web dashboard
+	//
+	// Redirects to the web dashboard
+	//
+	// ---
+	// produces:
+	// - text/html
+	// responses:
+	//   '302':
+	//     description: Redirect to the dashboard
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, DASHBOARD_PATH+"/index.html", http.StatusFound)
+	}
+}
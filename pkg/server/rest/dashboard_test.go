@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestDashboard(t *testing.T) {
+	c := newContract(t)
+
+	rec := httptest.NewRecorder()
+	c.server.router.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, rec.Code, http.StatusFound)
+	assert.Equal(t, rec.Header().Get("Location"), DASHBOARD_PATH+"/index.html")
+
+	for file, contentType := range map[string]string{
+		"index.html": "text/html",
+		"app.js":     "javascript",
+		"style.css":  "text/css",
+	} {
+		rec = httptest.NewRecorder()
+		c.server.router.ServeHTTP(rec, httptest.NewRequest("GET", DASHBOARD_PATH+"/"+file, nil))
+		assert.Equal(t, rec.Code, http.StatusOK, file)
+		assert.Check(t, is.Contains(rec.Header().Get("Content-Type"), contentType), file)
+	}
+
+	rec = httptest.NewRecorder()
+	c.server.router.ServeHTTP(rec, httptest.NewRequest("GET", DASHBOARD_PATH+"/missing.js", nil))
+	assert.Equal(t, rec.Code, http.StatusNotFound)
+}
+
+func TestStatusStream(t *testing.T) {
+	c := newContract(t)
+	server := httptest.NewServer(c.server.router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+STATUS_STREAM_PATH, nil)
+	assert.NilError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, res.Header.Get("Content-Type"), "text/event-stream")
+
+	events := bufio.NewScanner(res.Body)
+	next := func() models.Status {
+		t.Helper()
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				status := models.Status{}
+				assert.NilError(t, json.Unmarshal([]byte(data), &status))
+				return status
+			}
+		}
+		t.Fatalf("stream closed: %v", events.Err())
+		return models.Status{}
+	}
+
+	assert.Equal(t, next().State, models.State(0))
+
+	body := `{"state":1,"remaining":60000000000,"count":0,"n_pomodoros":2}`
+	post, err := http.Post(server.URL+STATUS_PATH, "application/json", strings.NewReader(body))
+	assert.NilError(t, err)
+	post.Body.Close()
+
+	status := next()
+	assert.Equal(t, status.State, models.RUNNING)
+	assert.Equal(t, status.NPomodoros, 2)
+}
+
+func TestStatusConcurrentRequests(t *testing.T) {
+	c := newContract(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				body := fmt.Sprintf(`{"state":%d,"count":%d,"n_pomodoros":4}`, models.RUNNING+models.State(j%2), i)
+				rec := httptest.NewRecorder()
+				c.server.router.ServeHTTP(rec, httptest.NewRequest("POST", STATUS_PATH, strings.NewReader(body)))
+				if rec.Code != http.StatusOK {
+					t.Errorf("POST %s: %d", STATUS_PATH, rec.Code)
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				rec := httptest.NewRecorder()
+				c.server.router.ServeHTTP(rec, httptest.NewRequest("GET", STATUS_PATH, nil))
+				status := models.Status{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil || status.NPomodoros%4 != 0 {
+					t.Errorf("GET %s: %s", STATUS_PATH, rec.Body)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// GoalSave saves a goal
+func (s *RestServer) GoalSave() http.HandlerFunc {
+
+	// swagger:operation POST /goals GoalSave
+	//
+	// Create Goal
+	//
+	// Creates a daily or weekly goal. Omit the ID to auto generate.
+	//
+	// ---
+	// parameters:
+	// - name: goal
+	//   in: body
+	//   description: Goal to Save
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Goal"
+	// responses:
+	//   '200':
+	//     description: Goal Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Goal"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		var goal = &models.Goal{}
+		if err := DecodeJSON(r.Body, goal); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if err := goal.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		goalID, err := s.store.GoalSave(ctx, goal)
+		if err != nil {
+			s.renderError(w, "GoalSave", err)
+			return
+		}
+		goal.ID = goalID
+
+		RenderJSON(w, http.StatusOK, goal)
+	}
+
+}
+
+// GoalsFind lists the goals
+func (s *RestServer) GoalsFind() http.HandlerFunc {
+
+	// swagger:operation GET /goals GoalsFind
+	//
+	// Find Goals
+	//
+	// Gets the list of goals
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Goal Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_GoalList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		goals, err := s.store.GoalList(ctx)
+		if err != nil {
+			s.renderError(w, "GoalsFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.GoalResults{Count: int64(len(goals)), Results: goals})
+	}
+
+}
+
+// GoalDeleteByID deletes a goal
+func (s *RestServer) GoalDeleteByID() http.HandlerFunc {
+
+	// swagger:operation DELETE /goals/{id} GoalDeleteByID
+	//
+	// Delete a Goal
+	//
+	// Deletes a Goal
+	//
+	// ---
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: Goal ID to delete
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		goalID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+		if err := s.store.GoalDeleteByID(ctx, goalID); err != nil {
+			s.renderError(w, "GoalDeleteByID", err)
+			return
+		}
+
+		RenderNoContent(w)
+	}
+
+}
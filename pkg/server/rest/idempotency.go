@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// idempotent records the response of the POST requests
+// carrying an Idempotency-Key header and sends it back when
+// the request is sent again instead of applying it twice
+func (s *RestServer) idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(models.IdempotencyKeyHeader)
+		if key == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// a replay sent while the request is applied waits for its response
+		s.idempotency.Lock()
+		defer s.idempotency.Unlock()
+
+		ctx := r.Context()
+		recorded, err := s.store.IdempotentResponseGet(ctx, key)
+		if err == nil {
+			if len(recorded.Body) > 0 {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			}
+			w.WriteHeader(recorded.Status)
+			w.Write(recorded.Body)
+			return
+		}
+		if !errors.Is(err, models.ErrNotFound) {
+			s.renderError(w, "Idempotency", err)
+			return
+		}
+
+		body := &bytes.Buffer{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(body)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		// a request that failed on the server may succeed when it is sent again
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = s.store.IdempotentResponseSave(ctx, &models.IdempotentResponse{
+			Key:    key,
+			Time:   time.Now(),
+			Status: status,
+			Body:   body.Bytes(),
+		})
+		if err != nil {
+			s.logger.Errorw("Could not record the response", "error", err, "key", key)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestIdempotentReplay(t *testing.T) {
+	c := newContract(t)
+	post := func(key string) (int, models.Task) {
+		raw, err := json.Marshal(models.Task{Message: "queued offline", NPomodoros: 1, Duration: time.Minute})
+		assert.NilError(t, err)
+		req := httptest.NewRequest(http.MethodPost, TASK_PATH, bytes.NewReader(raw))
+		if key != "" {
+			req.Header.Set(models.IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		c.server.router.ServeHTTP(rec, req)
+		task := models.Task{}
+		assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+		return rec.Code, task
+	}
+
+	code, first := post("replayed")
+	assert.Equal(t, code, http.StatusOK)
+	code, again := post("replayed")
+	assert.Equal(t, code, http.StatusOK)
+	assert.Equal(t, again.ID, first.ID, "the recorded response is sent back")
+	_, other := post("")
+	assert.Check(t, other.ID != first.ID, "requests without a key are applied")
+
+	tasks, err := c.server.store.GetAllTasks(context.Background())
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(tasks, 2))
+}
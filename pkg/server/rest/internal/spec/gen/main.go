@@ -0,0 +1,23 @@
+// Command gen writes the OpenAPI document of the REST server,
+// it is run by go generate in the directory of the handlers
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/server/rest/internal/spec"
+)
+
+func main() {
+	out := flag.String("o", "openapi.json", "file the document is written to")
+	flag.Parse()
+	document, err := spec.Generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, document, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
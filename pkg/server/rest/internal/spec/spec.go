@@ -0,0 +1,153 @@
+// Package spec builds the OpenAPI document of the REST server from the
+// swagger:operation comments of its handlers, in the format of go-swagger
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BaseFile holds the parts of the document other
+// than the paths, such as the info and definitions
+const BaseFile = "openapi.base.json"
+
+const annotation = "swagger:operation "
+
+// Generate returns the document of the handlers of the
+// package in dir, the paths are added to its BaseFile
+func Generate(dir string) ([]byte, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, BaseFile))
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", BaseFile, err)
+	}
+
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	paths := map[string]interface{}{}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range parsed.Comments {
+			lines := commentLines(group.List)
+			for i, line := range lines {
+				if !strings.HasPrefix(line, annotation) {
+					continue
+				}
+				method, path, op, err := operation(line, lines[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", fset.Position(group.Pos()), err)
+				}
+				item, _ := paths[path].(map[string]interface{})
+				if item == nil {
+					item = map[string]interface{}{}
+					paths[path] = item
+				}
+				if _, ok := item[method]; ok {
+					return nil, fmt.Errorf("%s: %s %s is documented twice", fset.Position(group.Pos()), method, path)
+				}
+				item[method] = op
+			}
+		}
+	}
+	document["paths"] = paths
+
+	out := new(bytes.Buffer)
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// commentLines returns the text of the line comments without
+// the slashes, keeping the indentation of the YAML
+func commentLines(list []*ast.Comment) []string {
+	lines := []string{}
+	for _, c := range list {
+		if !strings.HasPrefix(c.Text, "//") {
+			continue
+		}
+		line := strings.TrimPrefix(c.Text, "//")
+		lines = append(lines, strings.TrimPrefix(line, " "))
+	}
+	return lines
+}
+
+// operation parses the annotation line and the comment lines
+// following it: the summary, the description and the YAML
+// of the operation after a line of three dashes
+func operation(line string, lines []string) (string, string, map[string]interface{}, error) {
+	fields := strings.Fields(strings.TrimPrefix(line, annotation))
+	if len(fields) < 3 {
+		return "", "", nil, fmt.Errorf("expected %sMETHOD PATH [TAGS] ID, got %q", annotation, line)
+	}
+	method, path, id := strings.ToLower(fields[0]), fields[1], fields[len(fields)-1]
+	op := map[string]interface{}{"operationId": id}
+	if tags := fields[2 : len(fields)-1]; len(tags) > 0 {
+		op["tags"] = tags
+	}
+
+	var paragraphs []string
+	paragraph := []string{}
+	end := len(lines)
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			end = i
+			break
+		}
+		if strings.TrimSpace(line) == "" {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+				paragraph = []string{}
+			}
+			continue
+		}
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+	}
+	if len(paragraphs) > 0 {
+		op["summary"] = paragraphs[0]
+	}
+	if len(paragraphs) > 1 {
+		op["description"] = strings.Join(paragraphs[1:], "\n\n")
+	}
+	if end == len(lines) {
+		return method, path, op, nil
+	}
+
+	spec := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[end+1:], "\n")), &spec); err != nil {
+		return "", "", nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	for key, value := range spec {
+		op[key] = value
+	}
+	return method, path, op, nil
+}
@@ -11,7 +11,11 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-func loggerHTTPMiddlewareDefault(logRequestBody bool, logDuration bool) func(http.Handler) http.Handler {
+// requestHook is called after every request with the
+// status code written and the time spent serving it
+type requestHook func(r *http.Request, status int, duration time.Duration)
+
+func loggerHTTPMiddlewareDefault(logRequests bool, logRequestBody bool, logDuration bool, hooks ...requestHook) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -19,12 +23,20 @@ func loggerHTTPMiddlewareDefault(logRequestBody bool, logDuration bool) func(htt
 			writerWrap := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 			var response *bytes.Buffer
-			if logRequestBody {
+			if logRequests && logRequestBody {
 				response = new(bytes.Buffer)
 				writerWrap.Tee(response)
 			}
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(writerWrap, r)
+
+			duration := time.Since(start)
+			for _, hook := range hooks {
+				hook(r, writerWrap.Status(), duration)
+			}
+			if !logRequests {
+				return
+			}
 
 			fields := []zapcore.Field{
 				zap.Int("status", writerWrap.Status()),
@@ -44,7 +56,7 @@ func loggerHTTPMiddlewareDefault(logRequestBody bool, logDuration bool) func(htt
 				fields = append(fields, zap.ByteString("response", response.Bytes()))
 			}
 			if logDuration {
-				fields = append(fields, zap.Duration("duration", time.Since(start)))
+				fields = append(fields, zap.Duration("duration", duration))
 			}
 			zap.L().Debug("HTTP Request", fields...)
 		})
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var sessionStates = []models.State{models.RUNNING, models.BREAKING, models.COMPLETE, models.PAUSED}
+
+// metrics holds the prometheus collectors
+// exposed by the server on /metrics
+type metrics struct {
+	registry             *prometheus.Registry
+	pomodorosCompleted   *prometheus.CounterVec
+	pomodorosInterrupted *prometheus.CounterVec
+	tasksCreated         prometheus.Counter
+	sessionState         *prometheus.GaugeVec
+	remainingSeconds     prometheus.Gauge
+	requestDuration      *prometheus.HistogramVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		pomodorosCompleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pomo",
+			Name:      "pomodoros_completed_total",
+			Help:      "Number of pomodoros that ran for their full duration, by task tag.",
+		}, []string{"tag"}),
+		pomodorosInterrupted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pomo",
+			Name:      "pomodoros_interrupted_total",
+			Help:      "Number of pomodoros that ended before their duration, by task tag.",
+		}, []string{"tag"}),
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "pomo",
+			Name:      "tasks_created_total",
+			Help:      "Number of tasks created.",
+		}),
+		sessionState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "pomo",
+			Name:      "session_state",
+			Help:      "Current state of the pomodoro session, 1 for the active state.",
+		}, []string{"state"}),
+		remainingSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pomo",
+			Name:      "session_remaining_seconds",
+			Help:      "Seconds remaining in the current pomodoro.",
+		}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "pomo",
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests served by the REST API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+	m.registry.MustRegister(
+		m.pomodorosCompleted,
+		m.pomodorosInterrupted,
+		m.tasksCreated,
+		m.sessionState,
+		m.remainingSeconds,
+		m.requestDuration,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	for _, state := range sessionStates {
+		m.sessionState.WithLabelValues(state.String()).Set(0)
+	}
+	return m
+}
+
+// Handler serves the collected metrics
+func (m *metrics) Handler() http.Handler {
+	// swagger:operation GET /metrics Metrics
+	//
+	// Prometheus metrics
+	//
+	// Exposes the server metrics in the Prometheus text format
+	//
+	// ---
+	// produces:
+	// - text/plain
+	// responses:
+	//   '200':
+	//     description: Metrics
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// observeRequest is the requestHook recording request latency,
+// labelled with the route pattern to keep cardinality bounded
+func (m *metrics) observeRequest(r *http.Request, status int, duration time.Duration) {
+	route := "unknown"
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		route = rctx.RoutePattern()
+	}
+	m.requestDuration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// observePomodoro counts a saved pomodoro once for every tag of its task
+func (m *metrics) observePomodoro(task *models.Task, pomodoro *models.Pomodoro) {
+	counter := m.pomodorosCompleted
+	if task.Duration > 0 && pomodoro.Duration() < task.Duration {
+		counter = m.pomodorosInterrupted
+	}
+	if len(task.Tags) == 0 {
+		counter.WithLabelValues("").Inc()
+		return
+	}
+	for _, tag := range task.Tags {
+		counter.WithLabelValues(tag).Inc()
+	}
+}
+
+// observeStatus updates the session gauges
+func (m *metrics) observeStatus(status models.Status) {
+	for _, state := range sessionStates {
+		value := 0.0
+		if state == status.State {
+			value = 1
+		}
+		m.sessionState.WithLabelValues(state.String()).Set(value)
+	}
+	m.remainingSeconds.Set(status.Remaining.Seconds())
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestMetrics(t *testing.T) {
+	config := koanf.New(".")
+	assert.NilError(t, config.Load(confmap.Provider(map[string]interface{}{"server.metrics": true}, "."), nil))
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	t.Cleanup(func() { store.Close() })
+	s, err := newServer(config, store)
+	assert.NilError(t, err)
+
+	post := func(url string, body interface{}) []byte {
+		t.Helper()
+		raw, err := json.Marshal(body)
+		assert.NilError(t, err)
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, httptest.NewRequest("POST", url, bytes.NewReader(raw)))
+		assert.Equal(t, rec.Code, http.StatusOK, rec.Body.String())
+		return rec.Body.Bytes()
+	}
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(post(TASK_PATH, &models.Task{
+		Message: "write docs", Tags: []string{"work", "docs"}, NPomodoros: 2, Duration: 25 * time.Minute,
+	}), task))
+	start := time.Now().Add(-time.Hour)
+	pomodorosURL := fmt.Sprintf("/tasks/%d/pomodoros", task.ID)
+	post(pomodorosURL, models.Pomodoro{Start: start, End: start.Add(25 * time.Minute)})
+	post(pomodorosURL, models.Pomodoro{Start: start.Add(30 * time.Minute), End: start.Add(40 * time.Minute)})
+	post(STATUS_PATH, &models.Status{TaskID: task.ID, State: models.PAUSED, Remaining: 90 * time.Second, NPomodoros: 2})
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest("GET", METRICS_PATH, nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	scraped := rec.Body.String()
+	for _, line := range []string{
+		`pomo_tasks_created_total 1`,
+		`pomo_pomodoros_completed_total{tag="work"} 1`,
+		`pomo_pomodoros_completed_total{tag="docs"} 1`,
+		`pomo_pomodoros_interrupted_total{tag="work"} 1`,
+		`pomo_pomodoros_interrupted_total{tag="docs"} 1`,
+		`pomo_session_state{state="PAUSED"} 1`,
+		`pomo_session_state{state="RUNNING"} 0`,
+		`pomo_session_remaining_seconds 90`,
+		`pomo_http_request_duration_seconds_count{method="POST",route="/tasks",status="200"} 1`,
+		`pomo_http_request_duration_seconds_count{method="POST",route="/tasks/{id}/pomodoros",status="200"} 2`,
+		`pomo_http_request_duration_seconds_count{method="POST",route="/status",status="200"} 1`,
+	} {
+		assert.Check(t, is.Contains(strings.Split(scraped, "\n"), line))
+	}
+}
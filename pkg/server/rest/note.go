@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// NoteSave adds a note to a task
+func (s *RestServer) NoteSave() http.HandlerFunc {
+
+	// swagger:operation POST /tasks/{id}/notes NoteSave
+	//
+	// Add Note
+	//
+	// Adds a note to a task, or to one of its pomodoros when the pomodoro number is set (-1 for the last one).
+	//
+	// ---
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: Task ID the note is about
+	//   type: integer
+	//   required: true
+	// - name: note
+	//   in: body
+	//   description: Note to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Note"
+	// responses:
+	//   '200':
+	//     description: Note Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Note"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+		taskID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		var note = &models.Note{}
+		if err := DecodeJSON(r.Body, note); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		task, err := s.store.TaskGetByID(ctx, taskID)
+		if err == nil {
+			task.Pomodoros, err = s.store.PomodoroGetByTaskID(ctx, taskID)
+		}
+		if err == models.ErrNotFound {
+			RenderErrResourceNotFound(w, "task")
+			return
+		}
+		if err != nil {
+			s.renderError(w, "NoteSave", err)
+			return
+		}
+		if err := note.Validate(task); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if note.Time.IsZero() {
+			note.Time = time.Now()
+		}
+
+		if err := s.store.NoteSave(ctx, taskID, note); err != nil {
+			s.renderError(w, "NoteSave", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, note)
+	}
+
+}
@@ -0,0 +1,34 @@
+package rest
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:generate go run ./internal/spec/gen -o openapi.json
+
+// openAPISpec documents every route registered in Setup, it is
+// generated from the swagger:operation comments of the handlers
+// and openapi.base.json, run go generate after changing them
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPI returns the OpenAPI specification of the server
+func (s *RestServer) OpenAPI() http.HandlerFunc {
+	// swagger:operation GET /openapi.json OpenAPI
+	//
+	// OpenAPI specification
+	//
+	// Returns this document
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: OpenAPI specification
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(openAPISpec)
+	}
+}
@@ -0,0 +1,623 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/rest/internal/spec"
+	"github.com/joaorufino/pomo/pkg/server/webhook"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type schema = map[string]interface{}
+
+// contract serves requests through the router and checks
+// request and response bodies against the OpenAPI document
+type contract struct {
+	spec   schema
+	server *RestServer
+}
+
+func newContract(t *testing.T) *contract {
+	t.Helper()
+	spec := schema{}
+	assert.NilError(t, json.Unmarshal(openAPISpec, &spec))
+
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	t.Cleanup(func() { store.Close() })
+
+	s := &RestServer{
+		logger:       zap.S(),
+		router:       chi.NewRouter(),
+		store:        store,
+		statusBroker: newStatusBroker(),
+		controls:     make(chan models.Control, controlQueueSize),
+		webhooks:     webhook.New(store, conf.WebhooksConfig{}),
+		metrics:      newMetrics(),
+	}
+	assert.NilError(t, s.Setup())
+	return &contract{spec: spec, server: s}
+}
+
+// operation returns the spec of the route pattern and method
+func (c *contract) operation(route, method string) (schema, error) {
+	paths := c.spec["paths"].(schema)
+	item, ok := paths[route].(schema)
+	if !ok {
+		return nil, fmt.Errorf("path %s is not documented", route)
+	}
+	op, ok := item[strings.ToLower(method)].(schema)
+	if !ok {
+		return nil, fmt.Errorf("%s %s is not documented", method, route)
+	}
+	return op, nil
+}
+
+// do sends body to the server and returns the decoded response
+// after validating both against the operation of route
+func (c *contract) do(t *testing.T, method, url, route string, body interface{}) (int, []byte) {
+	t.Helper()
+	op, err := c.operation(route, method)
+	assert.NilError(t, err)
+
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		assert.NilError(t, err)
+		assert.NilError(t, c.validateBody(op, raw), "request %s %s", method, url)
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, url, reader)
+	rec := httptest.NewRecorder()
+	c.server.router.ServeHTTP(rec, req)
+
+	responses := op["responses"].(schema)
+	response, ok := responses[strconv.Itoa(rec.Code)].(schema)
+	if !ok {
+		response, ok = responses["default"].(schema)
+	}
+	assert.Assert(t, ok, "%s %s: status %d is not documented", method, url, rec.Code)
+	if responseSchema, ok := response["schema"].(schema); ok {
+		assert.NilError(t, c.validate(responseSchema, decode(t, rec.Body.Bytes()), "response"), "%s %s", method, url)
+	} else {
+		assert.Equal(t, rec.Body.Len(), 0, "%s %s: undocumented response body", method, url)
+	}
+	return rec.Code, rec.Body.Bytes()
+}
+
+func (c *contract) validateBody(op schema, raw []byte) error {
+	params, _ := op["parameters"].([]interface{})
+	for _, p := range params {
+		param := p.(schema)
+		if param["in"] == "body" {
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			return c.validate(param["schema"].(schema), value, "request")
+		}
+	}
+	return fmt.Errorf("operation %s does not accept a body", op["operationId"])
+}
+
+// validate checks value against the subset of JSON
+// schema used by the document
+func (c *contract) validate(s schema, value interface{}, at string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		definition, ok := c.spec["definitions"].(schema)[name].(schema)
+		if !ok {
+			return fmt.Errorf("%s: undefined reference %s", at, ref)
+		}
+		return c.validate(definition, value, at)
+	}
+	if value == nil {
+		if nullable, _ := s["x-nullable"].(bool); nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: unexpected null", at)
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if e == value {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", at, value, enum)
+		}
+	}
+	switch s["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", at, value)
+		}
+		properties, _ := s["properties"].(schema)
+		required, _ := s["required"].([]interface{})
+		for _, r := range required {
+			if _, ok := object[r.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %s", at, r)
+			}
+		}
+		for key, v := range object {
+			property, ok := properties[key].(schema)
+			if !ok {
+				return fmt.Errorf("%s: undocumented property %s", at, key)
+			}
+			if err := c.validate(property, v, at+"."+key); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", at, value)
+		}
+		for i, item := range array {
+			if err := c.validate(s["items"].(schema), item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string, got %T", at, value)
+		}
+		if s["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return fmt.Errorf("%s: %w", at, err)
+			}
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf("%s: expected integer, got %v", at, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", at, value)
+		}
+	}
+	return nil
+}
+
+func decode(t *testing.T, raw []byte) interface{} {
+	t.Helper()
+	var value interface{}
+	assert.NilError(t, json.Unmarshal(raw, &value))
+	return value
+}
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	c := newContract(t)
+	err := chi.Walk(c.server.router, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		_, err := c.operation(route, method)
+		return err
+	})
+	assert.NilError(t, err)
+}
+
+func TestOpenAPIGenerated(t *testing.T) {
+	generated, err := spec.Generate(".")
+	assert.NilError(t, err)
+	assert.Assert(t, bytes.Equal(generated, openAPISpec), "openapi.json is stale, run go generate ./pkg/server/rest")
+}
+
+func TestOpenAPIReferencesAreDefined(t *testing.T) {
+	c := newContract(t)
+	raw := string(openAPISpec)
+	for _, ref := range strings.Split(raw, `"$ref": "`)[1:] {
+		ref = ref[:strings.Index(ref, `"`)]
+		_, ok := c.spec["definitions"].(schema)[strings.TrimPrefix(ref, "#/definitions/")]
+		assert.Assert(t, ok, "undefined reference %s", ref)
+	}
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	c := newContract(t)
+	rec := httptest.NewRecorder()
+	c.server.router.ServeHTTP(rec, httptest.NewRequest("GET", OPENAPI_PATH, nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.DeepEqual(t, rec.Body.Bytes(), openAPISpec)
+}
+
+// TestRestClientContract replays the requests made by the
+// rest client and validates them against the document
+func TestRestClientContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+		Message:    "write the contract",
+		Tags:       []string{"docs"},
+		NPomodoros: 2,
+		Duration:   25 * time.Minute,
+	})
+	assert.Equal(t, code, http.StatusOK)
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, task))
+	taskURL := fmt.Sprintf("/tasks/%d", task.ID)
+	pomodorosURL := fmt.Sprintf("/tasks/%d/pomodoros", task.ID)
+
+	start := time.Now().Add(-25 * time.Minute)
+	code, _ = c.do(t, "POST", pomodorosURL, TASK_POMODOROS_PATH, models.Pomodoro{Start: start, End: time.Now()})
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "GET", pomodorosURL, TASK_POMODOROS_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+
+	code, raw = c.do(t, "GET", "/tasks", TASK_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	list := &models.ListResults{}
+	assert.NilError(t, json.Unmarshal(raw, list))
+	assert.Equal(t, list.Count, int64(1))
+
+	code, _ = c.do(t, "GET", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "POST", "/status", STATUS_PATH, &models.Status{State: models.RUNNING, Remaining: time.Minute, NPomodoros: 2})
+	assert.Equal(t, code, http.StatusOK)
+	code, _ = c.do(t, "GET", "/status", STATUS_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "DELETE", pomodorosURL, TASK_POMODOROS_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "DELETE", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+}
+
+// TestControlRelay queues controls as the command line does
+// and takes them as the client running the session does
+func TestControlRelay(t *testing.T) {
+	c := newContract(t)
+
+	code, _ := c.do(t, "POST", "/status/control", STATUS_CONTROL_PATH, &models.Control{Action: models.ControlSkip})
+	assert.Equal(t, code, http.StatusBadRequest, "no session is running")
+
+	code, _ = c.do(t, "POST", "/status", STATUS_PATH, &models.Status{State: models.RUNNING, Remaining: time.Minute, NPomodoros: 2})
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "POST", "/status/control", STATUS_CONTROL_PATH, &models.Control{Action: models.ControlExtend, Duration: time.Minute})
+	assert.Equal(t, code, http.StatusAccepted)
+
+	code, raw := c.do(t, "DELETE", "/status/control", STATUS_CONTROL_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	control := models.Control{}
+	assert.NilError(t, json.Unmarshal(raw, &control))
+	assert.DeepEqual(t, control, models.Control{Action: models.ControlExtend, Duration: time.Minute})
+
+	code, _ = c.do(t, "DELETE", "/status/control", STATUS_CONTROL_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+
+	// a control left when the session ends is dropped
+	code, _ = c.do(t, "POST", "/status/control", STATUS_CONTROL_PATH, &models.Control{Action: models.ControlSkip})
+	assert.Equal(t, code, http.StatusAccepted)
+	code, _ = c.do(t, "POST", "/status", STATUS_PATH, &models.Status{State: models.COMPLETE, NPomodoros: 2})
+	assert.Equal(t, code, http.StatusOK)
+	code, _ = c.do(t, "POST", "/status", STATUS_PATH, &models.Status{State: models.RUNNING, Remaining: time.Minute, NPomodoros: 2})
+	assert.Equal(t, code, http.StatusOK)
+	code, _ = c.do(t, "DELETE", "/status/control", STATUS_CONTROL_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+}
+
+func TestGoalsContract(t *testing.T) {
+	c := newContract(t)
+
+	code, _ := c.do(t, "POST", "/goals", GOAL_PATH, &models.Goal{Period: models.GoalDaily, Unit: models.GoalPomodoros})
+	assert.Equal(t, code, http.StatusBadRequest, "the target is missing")
+
+	code, raw := c.do(t, "POST", "/goals", GOAL_PATH, &models.Goal{Period: models.GoalWeekly, Unit: models.GoalHours, Target: 20, Tag: "docs"})
+	assert.Equal(t, code, http.StatusOK)
+	goal := models.Goal{}
+	assert.NilError(t, json.Unmarshal(raw, &goal))
+	assert.Assert(t, goal.ID > 0)
+	assert.Assert(t, goal.CreatedAt != nil)
+
+	code, raw = c.do(t, "GET", "/goals", GOAL_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	goals := models.GoalResults{}
+	assert.NilError(t, json.Unmarshal(raw, &goals))
+	assert.Assert(t, is.Len(goals.Results, 1))
+	// the zone of the time is the one sqlite reads it in
+	assert.Assert(t, goals.Results[0].CreatedAt != nil)
+	assert.Check(t, goals.Results[0].CreatedAt.Equal(*goal.CreatedAt))
+	goals.Results[0].CreatedAt = goal.CreatedAt
+	assert.DeepEqual(t, goals.Results, []models.Goal{goal})
+
+	goalURL := "/goals/" + strconv.Itoa(goal.ID)
+	code, _ = c.do(t, "DELETE", goalURL, GOAL_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+
+	code, raw = c.do(t, "GET", "/goals", GOAL_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	assert.NilError(t, json.Unmarshal(raw, &goals))
+	assert.Equal(t, goals.Count, int64(0))
+}
+
+func TestProjectsContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "POST", "/projects", PROJECT_PATH, &models.Project{Name: "Work", Color: "blue"})
+	assert.Equal(t, code, http.StatusOK)
+	work := models.Project{}
+	assert.NilError(t, json.Unmarshal(raw, &work))
+
+	code, _ = c.do(t, "POST", "/projects", PROJECT_PATH, &models.Project{Name: "Website", ParentID: work.ID + 1})
+	assert.Equal(t, code, http.StatusBadRequest, "the parent does not exist")
+
+	code, _ = c.do(t, "POST", "/projects", PROJECT_PATH, &models.Project{Name: "Website", ParentID: work.ID})
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "POST", "/projects/"+strconv.Itoa(work.ID)+"/archive", PROJECT_ARCHIVE_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "POST", "/projects/42/archive", PROJECT_ARCHIVE_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+
+	code, raw = c.do(t, "GET", "/projects", PROJECT_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	projects := models.ProjectResults{}
+	assert.NilError(t, json.Unmarshal(raw, &projects))
+	assert.Equal(t, len(projects.Results), 2)
+	for _, project := range projects.Results {
+		assert.Check(t, project.Archived, "project %d is not archived", project.ID)
+	}
+}
+
+func TestTagsContract(t *testing.T) {
+	c := newContract(t)
+
+	for _, tags := range [][]string{{"doc", "work"}, {"documentation"}, {"doc", "documentation"}} {
+		code, _ := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+			Message: "tagged", NPomodoros: 1, Duration: 25 * time.Minute, Tags: tags,
+		})
+		assert.Equal(t, code, http.StatusOK)
+	}
+	code, raw := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+		Message: "trashed", NPomodoros: 1, Duration: 25 * time.Minute, Tags: []string{"doc", "old"},
+	})
+	assert.Equal(t, code, http.StatusOK)
+	trashed := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, trashed))
+	code, _ = c.do(t, "DELETE", fmt.Sprintf("/tasks/%d", trashed.ID), TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+
+	code, _ = c.do(t, "POST", "/tags", TAG_PATH, &models.Tag{Name: "doc", Color: "teal"})
+	assert.Equal(t, code, http.StatusBadRequest, "the color is unknown")
+	code, _ = c.do(t, "POST", "/tags", TAG_PATH, &models.Tag{Name: "doc", Color: "208"})
+	assert.Equal(t, code, http.StatusNoContent)
+
+	code, _ = c.do(t, "POST", "/tags/rename", TAG_RENAME_PATH, &models.TagRename{From: []string{"doc"}, To: "a,b"})
+	assert.Equal(t, code, http.StatusBadRequest, "tags can not contain a comma")
+	code, _ = c.do(t, "POST", "/tags/rename", TAG_RENAME_PATH, &models.TagRename{From: []string{"doc", "documentation"}, To: "docs"})
+	assert.Equal(t, code, http.StatusNoContent)
+
+	code, raw = c.do(t, "GET", "/tags", TAG_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	tags := models.TagResults{}
+	assert.NilError(t, json.Unmarshal(raw, &tags))
+	assert.DeepEqual(t, tags.Results, models.Tags{
+		{Name: "docs", Color: "208", Count: 3},
+		{Name: "work", Count: 1},
+	})
+
+	code, raw = c.do(t, "GET", "/trash", TRASH_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	trash := &models.ListResults{}
+	assert.NilError(t, json.Unmarshal(raw, trash))
+	assert.Assert(t, is.Len(trash.Results, 1))
+	assert.DeepEqual(t, trash.Results[0].Tags, []string{"doc", "old"})
+}
+
+func TestTemplatesContract(t *testing.T) {
+	c := newContract(t)
+
+	standup := &models.Template{
+		Name: "standup", Message: "Stand-up", Tags: []string{"team"},
+		NPomodoros: 1, Duration: 15 * time.Minute, Recurrence: models.RecurWeekdays,
+	}
+	code, raw := c.do(t, "POST", "/templates", TEMPLATE_PATH, standup)
+	assert.Equal(t, code, http.StatusOK)
+	saved := models.Template{}
+	assert.NilError(t, json.Unmarshal(raw, &saved))
+
+	code, _ = c.do(t, "POST", "/templates", TEMPLATE_PATH, standup)
+	assert.Equal(t, code, http.StatusBadRequest, "the name is taken")
+
+	code, raw = c.do(t, "GET", "/templates", TEMPLATE_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	templates := models.TemplateResults{}
+	assert.NilError(t, json.Unmarshal(raw, &templates))
+	assert.Equal(t, len(templates.Results), 1)
+	assert.Equal(t, templates.Results[0].Recurrence, models.RecurWeekdays)
+
+	code, _ = c.do(t, "DELETE", "/templates/"+strconv.Itoa(saved.ID), TEMPLATE_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+}
+
+func TestNotesContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+		Message: "keep a journal", NPomodoros: 2, Duration: 25 * time.Minute,
+	})
+	assert.Equal(t, code, http.StatusOK)
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, task))
+	notesURL := fmt.Sprintf("/tasks/%d/notes", task.ID)
+
+	code, _ = c.do(t, "POST", notesURL, TASK_NOTES_PATH, models.Note{Text: "too early", Pomodoro: models.NoteLastPomodoro})
+	assert.Equal(t, code, http.StatusBadRequest, "there is no pomodoro yet")
+	code, _ = c.do(t, "POST", "/tasks/999/notes", TASK_NOTES_PATH, models.Note{Text: "lost"})
+	assert.Equal(t, code, http.StatusNotFound)
+
+	start := time.Now().Add(-25 * time.Minute)
+	code, _ = c.do(t, "POST", fmt.Sprintf("/tasks/%d/pomodoros", task.ID), TASK_POMODOROS_PATH, models.Pomodoro{Start: start, End: time.Now()})
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "POST", notesURL, TASK_NOTES_PATH, models.Note{Text: "drafted the outline"})
+	assert.Equal(t, code, http.StatusOK)
+	for _, text := range []string{"got distracted", "back on track"} {
+		code, raw = c.do(t, "POST", notesURL, TASK_NOTES_PATH, models.Note{Text: text, Pomodoro: models.NoteLastPomodoro})
+		assert.Equal(t, code, http.StatusOK)
+	}
+	note := models.Note{}
+	assert.NilError(t, json.Unmarshal(raw, &note))
+	assert.Equal(t, note.Pomodoro, 1)
+
+	code, raw = c.do(t, "GET", "/tasks", TASK_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	list := &models.ListResults{}
+	assert.NilError(t, json.Unmarshal(raw, list))
+	assert.Equal(t, len(list.Results[0].Notes), 1)
+	assert.Equal(t, list.Results[0].Notes[0].Text, "drafted the outline")
+	assert.Equal(t, list.Results[0].Pomodoros[0].Note, "got distracted\nback on track")
+}
+
+func TestPomodorosContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+		Message: "recover a lost session", NPomodoros: 2, Duration: 25 * time.Minute,
+	})
+	assert.Equal(t, code, http.StatusOK)
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, task))
+	pomodorosURL := fmt.Sprintf("/tasks/%d/pomodoros", task.ID)
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	code, _ = c.do(t, "POST", pomodorosURL, TASK_POMODOROS_PATH, models.Pomodoro{Start: start, End: start.Add(-time.Minute)})
+	assert.Equal(t, code, http.StatusBadRequest, "the pomodoro ends before it starts")
+	code, raw = c.do(t, "POST", fmt.Sprintf("/tasks/%d/pomodoros", task.ID+1), TASK_POMODOROS_PATH, models.Pomodoro{Start: start, End: start.Add(25 * time.Minute)})
+	assert.Equal(t, code, http.StatusNotFound, "no pomodoro without its task")
+	assert.Check(t, is.Contains(string(raw), "task"))
+
+	// entered by hand after the one they precede
+	later := models.Pomodoro{}
+	code, raw = c.do(t, "POST", pomodorosURL, TASK_POMODOROS_PATH, models.Pomodoro{Start: start.Add(time.Hour), End: start.Add(time.Hour + 25*time.Minute)})
+	assert.Equal(t, code, http.StatusOK)
+	assert.NilError(t, json.Unmarshal(raw, &later))
+	code, _ = c.do(t, "POST", pomodorosURL, TASK_POMODOROS_PATH, models.Pomodoro{Start: start, End: start.Add(25 * time.Minute)})
+	assert.Equal(t, code, http.StatusOK)
+
+	code, raw = c.do(t, "GET", pomodorosURL, TASK_POMODOROS_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	pomodoros := []models.Pomodoro{}
+	assert.NilError(t, json.Unmarshal(raw, &pomodoros))
+	assert.Equal(t, len(pomodoros), 2)
+	assert.Assert(t, pomodoros[0].Start.Equal(start))
+	assert.Equal(t, pomodoros[1].ID, later.ID)
+
+	query := models.PomodoroQuery{TaskID: task.ID, From: start.Add(30 * time.Minute)}
+	code, raw = c.do(t, "GET", "/pomodoros?"+query.Values().Encode(), POMODORO_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	found := models.PomodoroResults{}
+	assert.NilError(t, json.Unmarshal(raw, &found))
+	assert.Equal(t, found.Count, int64(1))
+	assert.Equal(t, found.Results[0].ID, later.ID)
+	code, _ = c.do(t, "GET", "/pomodoros?from=yesterday", POMODORO_PATH, nil)
+	assert.Equal(t, code, http.StatusBadRequest)
+
+	pomodoroURL := fmt.Sprintf("/pomodoros/%d", later.ID)
+	code, raw = c.do(t, "GET", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	fetched := models.Pomodoro{}
+	assert.NilError(t, json.Unmarshal(raw, &fetched))
+	assert.Equal(t, fetched.TaskID, task.ID)
+
+	fetched.End = fetched.Start.Add(20 * time.Minute)
+	code, _ = c.do(t, "PUT", pomodoroURL, POMODORO_ID_PATH, fetched)
+	assert.Equal(t, code, http.StatusOK)
+	code, raw = c.do(t, "GET", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	assert.NilError(t, json.Unmarshal(raw, &fetched))
+	assert.Equal(t, fetched.Duration(), 20*time.Minute)
+
+	code, _ = c.do(t, "DELETE", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "GET", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+	code, _ = c.do(t, "PUT", pomodoroURL, POMODORO_ID_PATH, fetched)
+	assert.Equal(t, code, http.StatusNotFound)
+	code, _ = c.do(t, "DELETE", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+}
+
+func TestTrashContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{
+		Message: "deleted by mistake", NPomodoros: 1, Duration: 25 * time.Minute,
+	})
+	assert.Equal(t, code, http.StatusOK)
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, task))
+	taskURL := fmt.Sprintf("/tasks/%d", task.ID)
+	restoreURL := taskURL + "/restore"
+
+	code, _ = c.do(t, "POST", restoreURL, TASK_RESTORE_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound, "the task is not in the trash")
+	code, raw = c.do(t, "POST", taskURL+"/pomodoros", TASK_POMODOROS_PATH, models.Pomodoro{Start: time.Now().Add(-time.Hour), End: time.Now()})
+	assert.Equal(t, code, http.StatusOK)
+	pomodoro := models.Pomodoro{}
+	assert.NilError(t, json.Unmarshal(raw, &pomodoro))
+	pomodoroURL := fmt.Sprintf("/pomodoros/%d", pomodoro.ID)
+
+	code, _ = c.do(t, "DELETE", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "GET", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound, "the pomodoros of a trashed task are hidden")
+	code, _ = c.do(t, "PUT", pomodoroURL, POMODORO_ID_PATH, pomodoro)
+	assert.Equal(t, code, http.StatusNotFound, "the pomodoros of a trashed task are not changed")
+	code, raw = c.do(t, "GET", "/tasks", TASK_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	list := &models.ListResults{}
+	assert.NilError(t, json.Unmarshal(raw, list))
+	assert.Equal(t, list.Count, int64(0))
+
+	code, raw = c.do(t, "GET", "/trash", TRASH_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	assert.NilError(t, json.Unmarshal(raw, list))
+	assert.Equal(t, list.Count, int64(1))
+	assert.Assert(t, list.Results[0].DeletedAt != nil)
+	code, _ = c.do(t, "POST", taskURL+"/pomodoros", TASK_POMODOROS_PATH, models.Pomodoro{Start: time.Now().Add(-time.Hour), End: time.Now()})
+	assert.Equal(t, code, http.StatusNotFound, "no pomodoro for a trashed task")
+
+	code, _ = c.do(t, "POST", restoreURL, TASK_RESTORE_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "GET", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	code, _ = c.do(t, "GET", pomodoroURL, POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+
+	code, _ = c.do(t, "DELETE", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "DELETE", "/trash", TRASH_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, raw = c.do(t, "GET", "/trash", TRASH_PATH, nil)
+	assert.Equal(t, code, http.StatusOK)
+	assert.NilError(t, json.Unmarshal(raw, list))
+	assert.Equal(t, list.Count, int64(0))
+	code, _ = c.do(t, "POST", restoreURL, TASK_RESTORE_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+}
@@ -11,28 +11,39 @@ import (
 // PomodoroSave saves a pomodoro
 func (s *RestServer) PomodoroSave() http.HandlerFunc {
 
-	// swagger:operation POST /api/pomodoros/{id} PomodoroSave
+	// swagger:operation POST /tasks/{id}/pomodoros PomodoroSave
 	//
 	// Create/Save Pomodoro
 	//
-	// Creates or saves a pomodoro. Omit the ID to auto generate.
-	// Pass an existing ID to update.
+	// Appends a pomodoro to a task, the runner saves each one it completes and lost ones can be entered by hand.
 	//
 	// ---
 	// parameters:
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Key of a request sent again, applied once
+	//   type: string
+	//   required: false
+	// - name: id
+	//   in: path
+	//   description: Task ID the pomodoro belongs to
+	//   type: integer
+	//   required: true
 	// - name: pomodoro
 	//   in: body
-	//   description: Pomodoro to Save/Update
+	//   description: Pomodoro to Save
 	//   required: true
-	//   type: object
 	//   schema:
 	//     "$ref": "#/definitions/models_PomodoroExample"
 	// responses:
 	//   '200':
 	//     description: Pomodoro Object
-	//     type: object
 	//     schema:
 	//       "$ref": "#/definitions/models_Pomodoro"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -40,6 +51,7 @@ func (s *RestServer) PomodoroSave() http.HandlerFunc {
 		taskID, err := strconv.Atoi(id)
 		if err != nil {
 			RenderErrInvalidRequest(w, err)
+			return
 		}
 
 		var pomodoro = new(models.Pomodoro)
@@ -47,47 +59,62 @@ func (s *RestServer) PomodoroSave() http.HandlerFunc {
 			RenderErrInvalidRequest(w, err)
 			return
 		}
+		if err := pomodoro.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
 
 		err = s.store.PomodoroSave(ctx, taskID, pomodoro)
 		if err != nil {
-			if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpSave))
+			if err == models.ErrNotFound {
+				RenderErrResourceNotFound(w, "task")
 			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("PomodoroSave error", "error", err, "error_id", errID)
+				s.renderError(w, "PomodoroSave", err)
 			}
 			return
 		}
+		if s.metrics != nil {
+			if task, err := s.store.TaskGetByID(ctx, taskID); err == nil {
+				s.metrics.observePomodoro(task, pomodoro)
+			}
+		}
+		event := models.PomodoroWithID{TaskID: taskID, Pomodoro: *pomodoro}
+		if err := s.webhooks.Publish(ctx, models.EventPomodoroCompleted, event); err != nil {
+			s.logger.Errorw("PomodoroSave webhook error", "error", err)
+		}
 
 		RenderJSON(w, http.StatusOK, pomodoro)
 	}
 
 }
 
-// PomodoroGetByID returns the pomodoro
-func (s *RestServer) PomodoroGetByID() http.HandlerFunc {
+// PomodoroGetByTaskID returns the pomodoros of a task
+func (s *RestServer) PomodoroGetByTaskID() http.HandlerFunc {
 
-	// swagger:operation GET /api/pomodoros/{id} PomodoroGetByID
+	// swagger:operation GET /tasks/{id}/pomodoros PomodoroGetByTaskID
 	//
-	// Get a Pomodoro
+	// Get the Pomodoros of a Task
 	//
-	// Fetches a Pomodoro
+	// Fetches the Pomodoros of a Task
 	//
 	// ---
-	// tags:
-	// - WIDGETS
 	// parameters:
 	// - name: id
 	//   in: path
-	//   description: Pomodoro ID to fetch
-	//   type: string
+	//   description: Task ID of the pomodoros to fetch
+	//   type: integer
 	//   required: true
 	// responses:
 	//   '200':
-	//     description: Pomodoro Object
-	//     type: object
+	//     description: Pomodoro Objects
 	//     schema:
-	//       "$ref": "#/definitions/models_Pomodoro"
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/models_Pomodoro"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -98,12 +125,9 @@ func (s *RestServer) PomodoroGetByID() http.HandlerFunc {
 		pomodoro, err := s.store.PomodoroGetByTaskID(ctx, taskID)
 		if err != nil {
 			if err == models.ErrNotFound {
-				RenderErrResourceNotFound(w, "pomodoro")
-			} else if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpGet))
+				RenderErrResourceNotFound(w, "task")
 			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("PomodoroGetByID error", "error", err, "error_id", errID)
+				s.renderError(w, "PomodoroGetByTaskID", err)
 			}
 			return
 		}
@@ -112,25 +136,29 @@ func (s *RestServer) PomodoroGetByID() http.HandlerFunc {
 	}
 }
 
-// PomodoroDeleteByID deletes a pomodoro
-func (s *RestServer) PomodoroDeleteByID() http.HandlerFunc {
+// PomodoroDeleteByTaskID deletes the pomodoros of a task
+func (s *RestServer) PomodoroDeleteByTaskID() http.HandlerFunc {
 
-	// swagger:operation DELETE /api/pomodoros/{id} PomodoroDeleteByID
+	// swagger:operation DELETE /tasks/{id}/pomodoros PomodoroDeleteByTaskID
 	//
-	// Delete a Pomodoro
+	// Delete the Pomodoros of a Task
 	//
-	// Deletes a Pomodoro
+	// Deletes the Pomodoros of a Task
 	//
 	// ---
 	// parameters:
 	// - name: id
 	//   in: path
-	//   description: Pomodoro ID to delete
-	//   type: string
+	//   description: Task ID of the pomodoros to delete
+	//   type: integer
 	//   required: true
 	// responses:
 	//   '204':
 	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -139,14 +167,228 @@ func (s *RestServer) PomodoroDeleteByID() http.HandlerFunc {
 		taskID, _ := strconv.Atoi(id)
 
 		err := s.store.PomodoroDeleteByTaskID(ctx, taskID)
+		if err != nil {
+			if err == models.ErrNotFound {
+				RenderErrResourceNotFound(w, "task")
+			} else {
+				s.renderError(w, "PomodoroDeleteByTaskID", err)
+			}
+			return
+		}
+
+		RenderNoContent(w)
+	}
+}
+
+// PomodorosFind returns the pomodoros matching the query
+func (s *RestServer) PomodorosFind() http.HandlerFunc {
+
+	// swagger:operation GET /pomodoros PomodorosFind
+	//
+	// Find Pomodoros
+	//
+	// Gets the pomodoros of a task, of a time range or both, in the order they were started
+	//
+	// ---
+	// parameters:
+	// - name: task_id
+	//   in: query
+	//   description: Task ID the pomodoros belong to
+	//   type: integer
+	//   required: false
+	// - name: from
+	//   in: query
+	//   description: Pomodoros started from this time
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// - name: to
+	//   in: query
+	//   description: Pomodoros started before this time
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// responses:
+	//   '200':
+	//     description: Pomodoro Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_PomodoroList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		query, err := models.ParsePomodoroQuery(r.URL.Query())
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		pomodoros, err := s.store.PomodoroList(ctx, query)
+		if err != nil {
+			s.renderError(w, "PomodorosFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.PomodoroResults{Count: int64(len(pomodoros)), Results: pomodoros})
+	}
+}
+
+// PomodoroGetByID returns a single pomodoro
+func (s *RestServer) PomodoroGetByID() http.HandlerFunc {
+
+	// swagger:operation GET /pomodoros/{pomodoroID} PomodoroGetByID
+	//
+	// Get a Pomodoro
+	//
+	// Fetches a single Pomodoro
+	//
+	// ---
+	// parameters:
+	// - name: pomodoroID
+	//   in: path
+	//   description: ID of the pomodoro to fetch
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '200':
+	//     description: Pomodoro Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Pomodoro"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		pomodoroID, err := strconv.Atoi(chi.URLParam(r, "pomodoroID"))
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		pomodoro, err := s.store.PomodoroGetByID(ctx, pomodoroID)
 		if err != nil {
 			if err == models.ErrNotFound {
 				RenderErrResourceNotFound(w, "pomodoro")
-			} else if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpDelete))
 			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("PomodoroDeleteByID error", "error", err, "error_id", errID)
+				s.renderError(w, "PomodoroGetByID", err)
+			}
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, pomodoro)
+	}
+}
+
+// PomodoroUpdate corrects a single pomodoro
+func (s *RestServer) PomodoroUpdate() http.HandlerFunc {
+
+	// swagger:operation PUT /pomodoros/{pomodoroID} PomodoroUpdate
+	//
+	// Update a Pomodoro
+	//
+	// Corrects the start, the end or the note of a single Pomodoro
+	//
+	// ---
+	// parameters:
+	// - name: pomodoroID
+	//   in: path
+	//   description: ID of the pomodoro to update
+	//   type: integer
+	//   required: true
+	// - name: pomodoro
+	//   in: body
+	//   description: Pomodoro to Save
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_PomodoroExample"
+	// responses:
+	//   '200':
+	//     description: Pomodoro Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Pomodoro"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		pomodoroID, err := strconv.Atoi(chi.URLParam(r, "pomodoroID"))
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		var pomodoro = new(models.Pomodoro)
+		if err := DecodeJSON(r.Body, pomodoro); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if err := pomodoro.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		pomodoro.ID = pomodoroID
+
+		if err := s.store.PomodoroUpdate(ctx, pomodoro); err != nil {
+			if err == models.ErrNotFound {
+				RenderErrResourceNotFound(w, "pomodoro")
+			} else {
+				s.renderError(w, "PomodoroUpdate", err)
+			}
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, pomodoro)
+	}
+}
+
+// PomodoroDeleteByID deletes a single pomodoro
+func (s *RestServer) PomodoroDeleteByID() http.HandlerFunc {
+
+	// swagger:operation DELETE /pomodoros/{pomodoroID} PomodoroDeleteByID
+	//
+	// Delete a Pomodoro
+	//
+	// Deletes a single Pomodoro
+	//
+	// ---
+	// parameters:
+	// - name: pomodoroID
+	//   in: path
+	//   description: ID of the pomodoro to delete
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		pomodoroID, err := strconv.Atoi(chi.URLParam(r, "pomodoroID"))
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		if err := s.store.PomodoroDeleteByID(ctx, pomodoroID); err != nil {
+			if err == models.ErrNotFound {
+				RenderErrResourceNotFound(w, "pomodoro")
+			} else {
+				s.renderError(w, "PomodoroDeleteByID", err)
 			}
 			return
 		}
@@ -157,7 +399,7 @@ func (s *RestServer) PomodoroDeleteByID() http.HandlerFunc {
 
 // GetStatus returns the server status
 func (s *RestServer) StatusGet() http.HandlerFunc {
-	// swagger:operation GET /api/status GetStatus
+	// swagger:operation GET /status GetStatus
 	//
 	// Get the server status
 	//
@@ -167,18 +409,24 @@ func (s *RestServer) StatusGet() http.HandlerFunc {
 	// responses:
 	//   '200':
 	//     description: Status Object
-	//     type: object
 	//     schema:
 	//       "$ref": "#/definitions/models_Status"
 	return func(w http.ResponseWriter, r *http.Request) {
-		RenderJSON(w, http.StatusOK, s.status)
+		RenderJSON(w, http.StatusOK, s.currentStatus())
 	}
 }
 
+// currentStatus returns the status saved last
+func (s *RestServer) currentStatus() models.Status {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+	return s.status
+}
+
 // StatusSave saves the server status
 func (s *RestServer) StatusSave() http.HandlerFunc {
 
-	// swagger:operation POST /api/status StatusSave
+	// swagger:operation POST /status StatusSave
 	//
 	// Save Status
 	//
@@ -186,19 +434,26 @@ func (s *RestServer) StatusSave() http.HandlerFunc {
 	//
 	// ---
 	// parameters:
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Key of a request sent again, applied once
+	//   type: string
+	//   required: false
 	// - name: status
 	//   in: body
 	//   description: Status to Save/Update
 	//   required: true
-	//   type: object
 	//   schema:
 	//     "$ref": "#/definitions/models_Status"
 	// responses:
 	//   '200':
 	//     description: Status Object
-	//     type: object
 	//     schema:
 	//       "$ref": "#/definitions/models_Status"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		var status = new(models.Status)
@@ -206,9 +461,22 @@ func (s *RestServer) StatusSave() http.HandlerFunc {
 			RenderErrInvalidRequest(w, err)
 			return
 		}
+		s.statusMu.Lock()
+		prev := s.status
 		s.status = *status
+		if !status.State.Active() {
+			s.dropControls()
+		}
+		s.statusBroker.publish(*status)
+		if s.metrics != nil {
+			s.metrics.observeStatus(*status)
+		}
+		if err := s.webhooks.PublishStatus(r.Context(), prev, *status); err != nil {
+			s.logger.Errorw("StatusSave webhook error", "error", err)
+		}
+		s.statusMu.Unlock()
 
-		RenderJSON(w, http.StatusOK, s.status)
+		RenderJSON(w, http.StatusOK, status)
 	}
 
 }
@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// ProjectSave saves a project
+func (s *RestServer) ProjectSave() http.HandlerFunc {
+
+	// swagger:operation POST /projects ProjectSave
+	//
+	// Create Project
+	//
+	// Creates a project, optionally nested under a parent. Omit the ID to auto generate.
+	//
+	// ---
+	// parameters:
+	// - name: project
+	//   in: body
+	//   description: Project to Save
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Project"
+	// responses:
+	//   '200':
+	//     description: Project Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Project"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		var project = &models.Project{}
+		if err := DecodeJSON(r.Body, project); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		projects, err := s.store.ProjectList(ctx)
+		if err != nil {
+			s.renderError(w, "ProjectSave", err)
+			return
+		}
+		if err := project.Validate(projects); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		projectID, err := s.store.ProjectSave(ctx, project)
+		if err != nil {
+			s.renderError(w, "ProjectSave", err)
+			return
+		}
+		project.ID = projectID
+
+		RenderJSON(w, http.StatusOK, project)
+	}
+
+}
+
+// ProjectsFind lists the projects
+func (s *RestServer) ProjectsFind() http.HandlerFunc {
+
+	// swagger:operation GET /projects ProjectsFind
+	//
+	// Find Projects
+	//
+	// Gets the list of projects, archived ones included
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Project Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_ProjectList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		projects, err := s.store.ProjectList(ctx)
+		if err != nil {
+			s.renderError(w, "ProjectsFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.ProjectResults{Count: int64(len(projects)), Results: projects})
+	}
+
+}
+
+// ProjectArchive archives a project and the projects nested under it
+func (s *RestServer) ProjectArchive() http.HandlerFunc {
+
+	// swagger:operation POST /projects/{id}/archive ProjectArchive
+	//
+	// Archive a Project
+	//
+	// Archives a Project and the projects nested under it
+	//
+	// ---
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: Project ID to archive
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		projectID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+		projects, err := s.store.ProjectList(ctx)
+		if err != nil {
+			s.renderError(w, "ProjectArchive", err)
+			return
+		}
+		if projects.ByID(projectID) == nil {
+			RenderErrResourceNotFound(w, "project")
+			return
+		}
+		for id := range projects.Subtree(projectID) {
+			if err := s.store.ProjectArchiveByID(ctx, id); err != nil {
+				s.renderError(w, "ProjectArchive", err)
+				return
+			}
+		}
+
+		RenderNoContent(w)
+	}
+
+}
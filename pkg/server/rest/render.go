@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/joaorufino/pomo/pkg/core/models"
 	"github.com/rs/xid"
 )
 
@@ -33,14 +34,16 @@ type ErrResponse struct {
 	Status  string `json:"status,omitempty"`
 	Error   string `json:"error,omitempty"`
 	ErrorID string `json:"error_id,omitempty"`
+	// models.ErrorType of the error, for the clients
+	Type string `json:"type,omitempty"`
 }
 
 func RenderErrNotFound(w http.ResponseWriter) {
-	RenderJSON(w, http.StatusNotFound, ErrResponse{Status: "not found", Error: "not found"})
+	RenderJSON(w, http.StatusNotFound, ErrResponse{Status: "not found", Error: "not found", Type: models.ErrorTypeNotFound.String()})
 }
 
 func RenderErrResourceNotFound(w http.ResponseWriter, resource string) {
-	RenderJSON(w, http.StatusNotFound, ErrResponse{Status: resource + " not found", Error: resource + " not found"})
+	RenderJSON(w, http.StatusNotFound, ErrResponse{Status: resource + " not found", Error: resource + " not found", Type: models.ErrorTypeNotFound.String()})
 }
 
 func RenderErrUnauthorized(w http.ResponseWriter) {
@@ -48,19 +51,46 @@ func RenderErrUnauthorized(w http.ResponseWriter) {
 }
 
 func RenderErrInvalidRequest(w http.ResponseWriter, err error) {
-	RenderJSON(w, http.StatusBadRequest, ErrResponse{Status: "invalid request", Error: errString(err)})
+	RenderJSON(w, http.StatusBadRequest, ErrResponse{Status: "invalid request", Error: errString(err), Type: models.ErrorTypeInvalid.String()})
 }
 
 func RenderErrInternal(w http.ResponseWriter, err error) {
-	RenderJSON(w, http.StatusInternalServerError, ErrResponse{Status: "internal error", Error: errString(err)})
+	RenderJSON(w, http.StatusInternalServerError, ErrResponse{Status: "internal error", Error: errString(err), Type: models.ErrorTypeInternal.String()})
 }
 
 func RenderErrInternalWithID(w http.ResponseWriter, err error) string {
 	errID := xid.New().String()
-	RenderJSON(w, http.StatusInternalServerError, ErrResponse{Status: "internal error", Error: errString(err), ErrorID: errID})
+	RenderJSON(w, http.StatusInternalServerError, ErrResponse{Status: "internal error", Error: errString(err), ErrorID: errID, Type: models.ErrorTypeInternal.String()})
 	return errID
 }
 
+// errorStatus is the status code and the status
+// of the responses to each type of error
+var errorStatus = map[models.ErrorType]struct {
+	code   int
+	status string
+}{
+	models.ErrorTypeNotFound:    {http.StatusNotFound, "not found"},
+	models.ErrorTypeInvalid:     {http.StatusBadRequest, "invalid request"},
+	models.ErrorTypeIncomplete:  {http.StatusBadRequest, "invalid request"},
+	models.ErrorTypeDuplicate:   {http.StatusConflict, "conflict"},
+	models.ErrorTypeForeignKey:  {http.StatusConflict, "conflict"},
+	models.ErrorTypeUnavailable: {http.StatusBadGateway, "bad gateway"},
+}
+
+// renderError renders the error of the operation with the status code
+// of its type, the other errors are internal: their message is kept
+// out of the response and logged with the keys and values under an ID
+func (s *RestServer) renderError(w http.ResponseWriter, operation string, err error, keysAndValues ...interface{}) {
+	errorType := models.TypeOf(err)
+	if status, ok := errorStatus[errorType]; ok {
+		RenderJSON(w, status.code, ErrResponse{Status: status.status, Error: errString(err), Type: errorType.String()})
+		return
+	}
+	errID := RenderErrInternalWithID(w, nil)
+	s.logger.Errorw(operation+" error", append([]interface{}{"error", err, "error_id", errID}, keysAndValues...)...)
+}
+
 func errString(err error) string {
 	if err == nil {
 		return ""
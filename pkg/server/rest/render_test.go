@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRenderError(t *testing.T) {
+	s := &RestServer{logger: zap.S()}
+	contexts := []struct {
+		err          error
+		expectedCode int
+		expectedType string
+		expectedBody string
+	}{
+		{models.ErrNotFound, http.StatusNotFound, "not_found", "not found"},
+		{models.NewError(models.ErrorTypeInvalid, "no message"), http.StatusBadRequest, "invalid", "no message"},
+		{models.NewError(models.ErrorTypeDuplicate, "UNIQUE constraint failed"), http.StatusConflict, "duplicate", "UNIQUE constraint failed"},
+		{models.NewError(models.ErrorTypeUnavailable, "connection refused"), http.StatusBadGateway, "unavailable", "connection refused"},
+		// the message of the internal errors is only logged
+		{models.NewError(models.ErrorTypeQuery, "no such table: task"), http.StatusInternalServerError, "internal", ""},
+		{errors.New("disk full"), http.StatusInternalServerError, "internal", ""},
+	}
+	for _, context := range contexts {
+		rec := httptest.NewRecorder()
+		s.renderError(rec, "Test", context.err)
+		assert.Equal(t, rec.Code, context.expectedCode, context.err.Error())
+		response := ErrResponse{}
+		assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, response.Type, context.expectedType)
+		assert.Equal(t, response.Error, context.expectedBody)
+	}
+}
+
+// TestNotFoundContract checks that missing tasks and pomodoros
+// are answered with a documented typed error
+func TestNotFoundContract(t *testing.T) {
+	c := newContract(t)
+
+	code, raw := c.do(t, "GET", "/pomodoros/42", POMODORO_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+	response := ErrResponse{}
+	assert.NilError(t, json.Unmarshal(raw, &response))
+	assert.Equal(t, response.Type, "not_found")
+
+	code, _ = c.do(t, "GET", "/tasks/42", TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+	code, _ = c.do(t, "DELETE", "/tasks/42", TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+	code, raw = c.do(t, "GET", "/tasks/42/pomodoros", TASK_POMODOROS_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+	assert.NilError(t, json.Unmarshal(raw, &response))
+	assert.Equal(t, response.Type, "not_found")
+	assert.Check(t, is.Contains(response.Error, "task"))
+	code, _ = c.do(t, "DELETE", "/tasks/42/pomodoros", TASK_POMODOROS_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound)
+
+	code, raw = c.do(t, "POST", "/tasks", TASK_PATH, &models.Task{Message: "trashed", NPomodoros: 1, Duration: time.Minute})
+	assert.Equal(t, code, http.StatusOK)
+	task := &models.Task{}
+	assert.NilError(t, json.Unmarshal(raw, task))
+	taskURL := fmt.Sprintf("/tasks/%d", task.ID)
+	code, _ = c.do(t, "DELETE", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNoContent)
+	code, _ = c.do(t, "GET", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound, "in the trash")
+	code, _ = c.do(t, "DELETE", taskURL, TASK_ID_PATH, nil)
+	assert.Equal(t, code, http.StatusNotFound, "already in the trash")
+}
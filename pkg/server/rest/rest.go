@@ -3,12 +3,19 @@ package rest
 import (
 	"net"
 	"net/http"
+	"sync"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/cors"
+	"github.com/joaorufino/pomo/pkg/conf"
 	"github.com/joaorufino/pomo/pkg/core"
 	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/backup"
+	"github.com/joaorufino/pomo/pkg/server/recurring"
+	"github.com/joaorufino/pomo/pkg/server/replication"
+	"github.com/joaorufino/pomo/pkg/server/trash"
+	"github.com/joaorufino/pomo/pkg/server/webhook"
 	"github.com/joaorufino/pomo/pkg/store"
 	"github.com/knadh/koanf"
 	"go.uber.org/zap"
@@ -16,37 +23,112 @@ import (
 
 // RestServer is the Rest web server
 type RestServer struct {
-	logger *zap.SugaredLogger
-	router chi.Router
-	conf   *koanf.Koanf
-	store  core.Store
-	server *http.Server
-	status models.Status
+	logger       *zap.SugaredLogger
+	router       chi.Router
+	conf         *koanf.Koanf
+	store        core.Store
+	server       *http.Server
+	status       models.Status
+	statusBroker *statusBroker
+	controls     chan models.Control
+	webhooks     *webhook.Dispatcher
+	recurring    *recurring.Scheduler
+	trash        *trash.Purger
+	backup       *backup.Scheduler
+	metrics      *metrics
+	// guards status, a change is published
+	// before the next one is saved
+	statusMu sync.RWMutex
+	// serializes the requests carrying an idempotency key
+	idempotency sync.Mutex
 }
 
 const (
-	TASK_PATH        = "/tasks"
-	TASK_ID_PATH     = TASK_PATH + "/{id}"
-	POMODORO_PATH    = "/pomodoros"
-	POMODORO_ID_PATH = POMODORO_PATH + "/{id}"
-	STATUS_PATH      = "/status"
+	TASK_PATH            = "/tasks"
+	TASK_ID_PATH         = TASK_PATH + "/{id}"
+	TASK_NOTES_PATH      = TASK_ID_PATH + "/notes"
+	TASK_POMODOROS_PATH  = TASK_ID_PATH + "/pomodoros"
+	TASK_RESTORE_PATH    = TASK_ID_PATH + "/restore"
+	TRASH_PATH           = "/trash"
+	SYNC_PATH            = replication.Path
+	SYNC_REMOTE_PATH     = SYNC_PATH + "/remote"
+	POMODORO_PATH        = "/pomodoros"
+	POMODORO_ID_PATH     = POMODORO_PATH + "/{pomodoroID}"
+	GOAL_PATH            = "/goals"
+	GOAL_ID_PATH         = GOAL_PATH + "/{id}"
+	PROJECT_PATH         = "/projects"
+	PROJECT_ARCHIVE_PATH = PROJECT_PATH + "/{id}/archive"
+	TAG_PATH             = "/tags"
+	TAG_RENAME_PATH      = TAG_PATH + "/rename"
+	TEMPLATE_PATH        = "/templates"
+	TEMPLATE_ID_PATH     = TEMPLATE_PATH + "/{id}"
+	STATUS_PATH          = "/status"
+	STATUS_STREAM_PATH   = STATUS_PATH + "/stream"
+	STATUS_CONTROL_PATH  = STATUS_PATH + "/control"
+	METRICS_PATH         = "/metrics"
+	OPENAPI_PATH         = "/openapi.json"
+	DASHBOARD_PATH       = "/dashboard"
 )
 
 // Setup will setup the API listener
 func (s *RestServer) Setup() error {
 
+	s.router.Use(s.idempotent)
+
 	// Base Functions
 	s.router.Get(TASK_PATH, s.TasksFind())
 	s.router.Post(TASK_PATH, s.TaskSave())
 	s.router.Get(TASK_ID_PATH, s.TaskGetByID())
 	s.router.Delete(TASK_ID_PATH, s.TaskDeleteByID())
+	s.router.Post(TASK_NOTES_PATH, s.NoteSave())
+	s.router.Post(TASK_RESTORE_PATH, s.TaskRestoreByID())
+
+	s.router.Get(TRASH_PATH, s.TrashFind())
+	s.router.Delete(TRASH_PATH, s.TrashEmpty())
+
+	s.router.Get(SYNC_PATH, s.SyncExport())
+	s.router.Post(SYNC_PATH, s.SyncMerge())
+	s.router.Post(SYNC_REMOTE_PATH, s.SyncRemote())
+
+	s.router.Post(TASK_POMODOROS_PATH, s.PomodoroSave())
+	s.router.Get(TASK_POMODOROS_PATH, s.PomodoroGetByTaskID())
+	s.router.Delete(TASK_POMODOROS_PATH, s.PomodoroDeleteByTaskID())
 
-	s.router.Post(POMODORO_ID_PATH, s.PomodoroSave())
+	s.router.Get(POMODORO_PATH, s.PomodorosFind())
 	s.router.Get(POMODORO_ID_PATH, s.PomodoroGetByID())
+	s.router.Put(POMODORO_ID_PATH, s.PomodoroUpdate())
 	s.router.Delete(POMODORO_ID_PATH, s.PomodoroDeleteByID())
 
+	s.router.Get(GOAL_PATH, s.GoalsFind())
+	s.router.Post(GOAL_PATH, s.GoalSave())
+	s.router.Delete(GOAL_ID_PATH, s.GoalDeleteByID())
+
+	s.router.Get(PROJECT_PATH, s.ProjectsFind())
+	s.router.Post(PROJECT_PATH, s.ProjectSave())
+	s.router.Post(PROJECT_ARCHIVE_PATH, s.ProjectArchive())
+
+	s.router.Get(TAG_PATH, s.TagsFind())
+	s.router.Post(TAG_PATH, s.TagSave())
+	s.router.Post(TAG_RENAME_PATH, s.TagRename())
+
+	s.router.Get(TEMPLATE_PATH, s.TemplatesFind())
+	s.router.Post(TEMPLATE_PATH, s.TemplateSave())
+	s.router.Delete(TEMPLATE_ID_PATH, s.TemplateDeleteByID())
+
 	s.router.Get(STATUS_PATH, s.StatusGet())
 	s.router.Post(STATUS_PATH, s.StatusSave())
+	s.router.Get(STATUS_STREAM_PATH, s.StatusStream())
+	s.router.Post(STATUS_CONTROL_PATH, s.ControlSend())
+	s.router.Delete(STATUS_CONTROL_PATH, s.ControlNext())
+
+	s.router.Get("/", s.DashboardRedirect())
+	s.router.Get(DASHBOARD_PATH+"/{file}", s.Dashboard())
+
+	s.router.Get(OPENAPI_PATH, s.OpenAPI())
+
+	if s.metrics != nil {
+		s.router.Method("GET", METRICS_PATH, s.metrics.Handler())
+	}
 
 	return nil
 
@@ -55,13 +137,35 @@ func (s *RestServer) Setup() error {
 // New will setup the API listener
 func New(config *koanf.Koanf) (core.Server, error) {
 
+	store, err := store.NewStore(config)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(config, store)
+
+}
+
+// newServer sets up the API on top of an opened store, bringing its schema up to date
+func newServer(config *koanf.Koanf, store core.Store) (*RestServer, error) {
+
+	if err := store.InitDB(); err != nil {
+		return nil, err
+	}
+
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Recoverer)
 
+	var hooks []requestHook
+	var m *metrics
+	if config.Bool("server.metrics") {
+		m = newMetrics()
+		hooks = append(hooks, m.observeRequest)
+	}
+
 	// Log Requests - Use appropriate format depending on the encoding
-	if config.Bool("server.log_requests") {
-		r.Use(loggerHTTPMiddlewareDefault(config.Bool("server.log_requests_body"), config.Bool("server.log_duration")))
+	if config.Bool("server.log_requests") || len(hooks) > 0 {
+		r.Use(loggerHTTPMiddlewareDefault(config.Bool("server.log_requests"), config.Bool("server.log_requests_body"), config.Bool("server.log_duration"), hooks...))
 	}
 
 	// CORS Config
@@ -73,15 +177,40 @@ func New(config *koanf.Koanf) (core.Server, error) {
 		MaxAge:           config.Int("server.cors.max_age"),
 	}).Handler)
 
-	store, _ := store.NewStore(config)
+	var webhooks conf.WebhooksConfig
+	if err := config.Unmarshal("webhooks", &webhooks); err != nil {
+		return nil, err
+	}
 
 	s := &RestServer{
-		conf:   config,
-		logger: zap.S().With("package", "restServer"),
-		router: r,
-		store:  store,
+		conf:         config,
+		logger:       zap.S().With("package", "restServer"),
+		router:       r,
+		store:        store,
+		statusBroker: newStatusBroker(),
+		controls:     make(chan models.Control, controlQueueSize),
+		webhooks:     webhook.New(store, webhooks),
+		metrics:      m,
 	}
 
+	var recurringConfig conf.RecurringConfig
+	if err := config.Unmarshal("recurring", &recurringConfig); err != nil {
+		return nil, err
+	}
+	s.recurring = recurring.New(store, s.webhooks, recurringConfig)
+
+	var trashConfig conf.TrashConfig
+	if err := config.Unmarshal("trash", &trashConfig); err != nil {
+		return nil, err
+	}
+	s.trash = trash.New(store, trashConfig)
+
+	var backupConfig conf.BackupConfig
+	if err := config.Unmarshal("backup", &backupConfig); err != nil {
+		return nil, err
+	}
+	s.backup = backup.New(store, backupConfig)
+
 	// RestInterface
 	if err := s.Setup(); err != nil {
 		s.logger.Fatalf("Could not setup rest interface: %v", err)
@@ -110,6 +239,11 @@ func (s *RestServer) Start() {
 		}
 	}()
 	s.logger.Infow("API Listening", "address", s.server.Addr, "tls", s.conf.Bool("server.tls"))
+
+	s.webhooks.Start()
+	s.recurring.Start()
+	s.trash.Start()
+	s.backup.Start()
 }
 
 // Router returns the router
@@ -118,6 +252,10 @@ func (s *RestServer) Router() chi.Router {
 }
 
 func (s *RestServer) Stop() {
+	s.backup.Stop()
+	s.trash.Stop()
+	s.recurring.Stop()
+	s.webhooks.Stop()
 	s.server.Close()
 	s.store.Close()
 }
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"github.com/knadh/koanf"
+	_ "github.com/mattn/go-sqlite3"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// baselineSchema is the schema written by the first releases of pomo
+const baselineSchema = `
+    CREATE TABLE task (
+	message TEXT,
+	pomodoros INTEGER,
+	duration TEXT,
+	tags TEXT
+    );
+    CREATE TABLE pomodoro (
+	task_id INTEGER,
+	start DATETTIME,
+	end DATETTIME
+    );
+    `
+
+func TestNewServerMigrates(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "pomo.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NilError(t, err)
+	_, err = db.Exec(baselineSchema)
+	assert.NilError(t, err)
+	start := time.Now().Add(-time.Hour)
+	_, err = db.Exec("INSERT INTO task (message,pomodoros,duration,tags) VALUES ($1,$2,$3,$4)", "old task", 2, "25m0s", "work")
+	assert.NilError(t, err)
+	_, err = db.Exec("INSERT INTO pomodoro (task_id, start, end) VALUES ($1, $2, $3)", 1, start, start.Add(25*time.Minute))
+	assert.NilError(t, err)
+	assert.NilError(t, db.Close())
+
+	store, err := sqlite.NewStore(dbPath)
+	assert.NilError(t, err)
+	t.Cleanup(func() { store.Close() })
+	s, err := newServer(koanf.New("."), store)
+	assert.NilError(t, err)
+
+	serve := func(method, url, body string) (int, []byte) {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.Bytes()
+	}
+
+	code, raw := serve("GET", TASK_PATH, "")
+	assert.Equal(t, code, http.StatusOK, string(raw))
+	list := models.ListResults{}
+	assert.NilError(t, json.Unmarshal(raw, &list))
+	tasks := list.Results
+	assert.Assert(t, is.Len(tasks, 1))
+	assert.Equal(t, tasks[0].Message, "old task")
+	assert.Assert(t, tasks[0].UUID != "")
+	assert.Assert(t, is.Len(tasks[0].Pomodoros, 1))
+
+	code, raw = serve("POST", TASK_PATH, `{"message":"new task","n_pomodoros":1,"duration":1500000000000}`)
+	assert.Equal(t, code, http.StatusOK, string(raw))
+
+	for _, url := range []string{TRASH_PATH, GOAL_PATH, PROJECT_PATH, TAG_PATH, TEMPLATE_PATH, TASK_PATH + "/1", TASK_PATH + "/1/pomodoros"} {
+		code, raw = serve("GET", url, "")
+		assert.Equal(t, code, http.StatusOK, "GET %s: %s", url, raw)
+	}
+}
@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// statusBroker fans status updates out to the
+// clients following the status stream
+type statusBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan models.Status]struct{}
+}
+
+func newStatusBroker() *statusBroker {
+	return &statusBroker{subscribers: make(map[chan models.Status]struct{})}
+}
+
+func (b *statusBroker) subscribe() chan models.Status {
+	ch := make(chan models.Status, 1)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *statusBroker) unsubscribe(ch chan models.Status) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
+
+// publish hands the status to every subscriber, a slow
+// subscriber only ever misses intermediate updates
+func (b *statusBroker) publish(status models.Status) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- status
+	}
+}
+
+// StatusStream streams the server status as server-sent events
+func (s *RestServer) StatusStream() http.HandlerFunc {
+	// swagger:operation GET /status/stream StatusStream
+	//
+	// Stream the server status
+	//
+	// Sends a status event with the current status and
+	// another one every time the status is saved
+	//
+	// ---
+	// produces:
+	// - text/event-stream
+	// responses:
+	//   '200':
+	//     description: Stream of Status Objects
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			RenderErrInternal(w, fmt.Errorf("streaming is not supported"))
+			return
+		}
+		updates := s.statusBroker.subscribe()
+		defer s.statusBroker.unsubscribe(updates)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+
+		status := s.currentStatus()
+		for {
+			raw, err := json.Marshal(status)
+			if err != nil {
+				s.logger.Errorw("StatusStream error", "error", err)
+				return
+			}
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", raw)
+			flusher.Flush()
+		wait:
+			select {
+			case <-r.Context().Done():
+				return
+			case status = <-updates:
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				flusher.Flush()
+				goto wait
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/replication"
+)
+
+// SyncExport sends what another server needs to sync
+func (s *RestServer) SyncExport() http.HandlerFunc {
+
+	// swagger:operation GET /sync SyncExport
+	//
+	// Export for a Sync
+	//
+	// Gets every task, the trashed ones too, with its pomodoros and the tombstones of the deleted ones
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Sync Batch
+	//     schema:
+	//       "$ref": "#/definitions/models_SyncBatch"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		batch, err := s.store.SyncExport(r.Context())
+		if err != nil {
+			s.renderError(w, "SyncExport", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, batch)
+	}
+}
+
+// SyncMerge applies the batch exported by another server
+func (s *RestServer) SyncMerge() http.HandlerFunc {
+
+	// swagger:operation POST /sync SyncMerge
+	//
+	// Merge a Sync
+	//
+	// Applies the batch exported by another server, the newest change of a task or a pomodoro is kept
+	//
+	// ---
+	// parameters:
+	// - name: batch
+	//   in: body
+	//   description: Batch exported by the other server
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_SyncBatch"
+	// responses:
+	//   '200':
+	//     description: Changes made by the merge
+	//     schema:
+	//       "$ref": "#/definitions/models_SyncResult"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var batch = &models.SyncBatch{}
+		if err := DecodeJSON(r.Body, batch); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		result, err := s.store.SyncMerge(r.Context(), batch)
+		if err != nil {
+			s.renderError(w, "SyncMerge", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, result)
+	}
+}
+
+// SyncRemote syncs the server with a remote one
+func (s *RestServer) SyncRemote() http.HandlerFunc {
+
+	// swagger:operation POST /sync/remote SyncRemote
+	//
+	// Sync with a Server
+	//
+	// Exchanges the tasks, pomodoros and notes with the pomo server at the remote URL
+	//
+	// ---
+	// parameters:
+	// - name: remote
+	//   in: body
+	//   description: Server to sync with
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_SyncRemote"
+	// responses:
+	//   '200':
+	//     description: Changes made on both servers
+	//     schema:
+	//       "$ref": "#/definitions/models_SyncReport"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		var remote = &models.SyncRemote{}
+		if err := DecodeJSON(r.Body, remote); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if remote.Remote == "" {
+			RenderErrInvalidRequest(w, errors.New("remote is required"))
+			return
+		}
+
+		report, err := replication.Sync(r.Context(), s.store, remote.Remote)
+		if err != nil {
+			// a remote server that failed is answered with a bad gateway
+			s.renderError(w, "SyncRemote", err, "remote", remote.Remote)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, report)
+	}
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestSyncServers(t *testing.T) {
+	laptop, desktop := newContract(t), newContract(t)
+	remote := httptest.NewServer(desktop.server.router)
+	defer remote.Close()
+	ctx := context.Background()
+
+	sync := func() models.SyncReport {
+		t.Helper()
+		code, raw := laptop.do(t, "POST", SYNC_REMOTE_PATH, SYNC_REMOTE_PATH, models.SyncRemote{Remote: remote.URL})
+		assert.Equal(t, code, http.StatusOK, string(raw))
+		report := models.SyncReport{}
+		assert.NilError(t, json.Unmarshal(raw, &report))
+		return report
+	}
+	save := func(store core.Store, message string) (int, *models.Pomodoro) {
+		t.Helper()
+		taskID, err := store.TaskSave(ctx, &models.Task{Message: message, NPomodoros: 2, Duration: time.Minute})
+		assert.NilError(t, err)
+		start := time.Now().Add(-time.Hour)
+		pomodoro := &models.Pomodoro{Start: start, End: start.Add(time.Minute)}
+		assert.NilError(t, store.PomodoroSave(ctx, taskID, pomodoro))
+		return taskID, pomodoro
+	}
+	byMessage := func(store core.Store) map[string]models.Task {
+		t.Helper()
+		batch, err := store.SyncExport(ctx)
+		assert.NilError(t, err)
+		tasks := map[string]models.Task{}
+		for _, task := range batch.Tasks {
+			tasks[task.Message] = task
+		}
+		return tasks
+	}
+
+	laptopTaskID, laptopPomodoro := save(laptop.server.store, "written on the laptop")
+	desktopTaskID, _ := save(desktop.server.store, "written on the desktop")
+	// a zone other than the one the times are read in
+	noted := time.Now().In(time.FixedZone("UTC+2", 2*60*60)).Truncate(time.Second)
+	assert.NilError(t, laptop.server.store.NoteSave(ctx, laptopTaskID, &models.Note{Time: noted, Text: "journal"}))
+	report := sync()
+	assert.Equal(t, report.Local, models.SyncResult{Created: 2})
+	assert.Equal(t, report.Remote, models.SyncResult{Created: 3})
+	notes, err := desktop.server.store.NoteGetByTaskID(ctx, byMessage(desktop.server.store)["written on the laptop"].ID)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(notes, 1))
+	assert.Check(t, notes[0].Time.Equal(noted))
+	assert.Check(t, is.Equal(notes[0].Text, "journal"))
+	for _, store := range []core.Store{laptop.server.store, desktop.server.store} {
+		tasks := byMessage(store)
+		assert.Assert(t, is.Len(tasks, 2))
+		for _, task := range tasks {
+			assert.Check(t, is.Len(task.Pomodoros, 1))
+		}
+	}
+	assert.Equal(t, byMessage(desktop.server.store)["written on the laptop"].UUID, byMessage(laptop.server.store)["written on the laptop"].UUID)
+
+	// the last change of the pomodoro wins on both servers
+	copied := byMessage(desktop.server.store)["written on the laptop"].Pomodoros[0]
+	laptopPomodoro.Note = "older"
+	assert.NilError(t, laptop.server.store.PomodoroUpdate(ctx, laptopPomodoro))
+	copied.Note = "newer"
+	assert.NilError(t, desktop.server.store.PomodoroUpdate(ctx, copied))
+	// the desktop trashes its task and deletes the pomodoro of the laptop's one
+	assert.NilError(t, desktop.server.store.TaskDeleteByID(ctx, desktopTaskID))
+	report = sync()
+	assert.Equal(t, report.Local, models.SyncResult{Updated: 2})
+	assert.Equal(t, report.Remote, models.SyncResult{})
+	for _, store := range []core.Store{laptop.server.store, desktop.server.store} {
+		tasks := byMessage(store)
+		assert.Check(t, is.Equal(tasks["written on the laptop"].Pomodoros[0].Note, "newer"))
+		assert.Check(t, tasks["written on the desktop"].DeletedAt != nil, "trashed on both")
+	}
+
+	assert.NilError(t, desktop.server.store.PomodoroDeleteByID(ctx, copied.ID))
+	report = sync()
+	assert.Equal(t, report.Local, models.SyncResult{Deleted: 1})
+	assert.Check(t, is.Len(byMessage(laptop.server.store)["written on the laptop"].Pomodoros, 0))
+	assert.Check(t, is.Len(byMessage(desktop.server.store)["written on the laptop"].Pomodoros, 0), "not copied back")
+
+	report = sync()
+	assert.Equal(t, report, models.SyncReport{}, "both servers are in sync")
+	notes, err = laptop.server.store.NoteGetByTaskID(ctx, laptopTaskID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(notes, 1), "not copied back")
+}
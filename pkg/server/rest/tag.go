@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TagsFind lists the tags
+func (s *RestServer) TagsFind() http.HandlerFunc {
+
+	// swagger:operation GET /tags TagsFind
+	//
+	// Find Tags
+	//
+	// Gets the tags of the tasks and their colors
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Tag Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_TagList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		tags, err := s.store.TagList(ctx)
+		if err != nil {
+			s.renderError(w, "TagsFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.TagResults{Count: int64(len(tags)), Results: tags})
+	}
+
+}
+
+// TagSave sets the color of a tag
+func (s *RestServer) TagSave() http.HandlerFunc {
+
+	// swagger:operation POST /tags TagSave
+	//
+	// Set a Tag Color
+	//
+	// Sets the color of a tag, an empty color removes it
+	//
+	// ---
+	// parameters:
+	// - name: tag
+	//   in: body
+	//   description: Tag to Save
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Tag"
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		var tag = &models.Tag{}
+		if err := DecodeJSON(r.Body, tag); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if err := tag.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		if err := s.store.TagSave(ctx, tag); err != nil {
+			s.renderError(w, "TagSave", err)
+			return
+		}
+
+		RenderNoContent(w)
+	}
+
+}
+
+// TagRename renames tags or merges them into one
+func (s *RestServer) TagRename() http.HandlerFunc {
+
+	// swagger:operation POST /tags/rename TagRename
+	//
+	// Rename Tags
+	//
+	// Replaces the from tags of every task by the to tag, merging them when there are several
+	//
+	// ---
+	// parameters:
+	// - name: rename
+	//   in: body
+	//   description: Tags to rename
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_TagRename"
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		var rename = &models.TagRename{}
+		if err := DecodeJSON(r.Body, rename); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		if err := rename.Validate(); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		if err := s.store.TagRename(ctx, *rename); err != nil {
+			s.renderError(w, "TagRename", err)
+			return
+		}
+
+		RenderNoContent(w)
+	}
+
+}
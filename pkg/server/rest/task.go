@@ -11,7 +11,7 @@ import (
 // TaskSave saves a task
 func (s *RestServer) TaskSave() http.HandlerFunc {
 
-	// swagger:operation POST /api/tasks TaskSave
+	// swagger:operation POST /tasks TaskSave
 	//
 	// Create/Save Task
 	//
@@ -19,19 +19,26 @@ func (s *RestServer) TaskSave() http.HandlerFunc {
 	//
 	// ---
 	// parameters:
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Key of a request sent again, applied once
+	//   type: string
+	//   required: false
 	// - name: task
 	//   in: body
 	//   description: Task to Save/Update
 	//   required: true
-	//   type: object
 	//   schema:
 	//     "$ref": "#/definitions/models_TaskExample"
 	// responses:
 	//   '200':
-	//     description: User Object
-	//     type: object
+	//     description: Task Object
 	//     schema:
 	//       "$ref": "#/definitions/models_Task"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -44,15 +51,16 @@ func (s *RestServer) TaskSave() http.HandlerFunc {
 
 		taskID, err := s.store.TaskSave(ctx, task)
 		if err != nil {
-			if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpSave))
-			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("TaskSave error", "error", err, "error_id", errID)
-			}
+			s.renderError(w, "TaskSave", err)
 			return
 		}
 		task.ID = taskID
+		if s.metrics != nil {
+			s.metrics.tasksCreated.Inc()
+		}
+		if err := s.webhooks.Publish(ctx, models.EventTaskCreated, task); err != nil {
+			s.logger.Errorw("TaskSave webhook error", "error", err)
+		}
 
 		RenderJSON(w, http.StatusOK, task)
 	}
@@ -61,7 +69,7 @@ func (s *RestServer) TaskSave() http.HandlerFunc {
 
 // TaskGetByID returns the task
 func (s *RestServer) TaskGetByID() http.HandlerFunc {
-	// swagger:operation GET /api/tasks/{id} TaskGetByID
+	// swagger:operation GET /tasks/{id} TaskGetByID
 	//
 	// Get a Task
 	//
@@ -72,14 +80,17 @@ func (s *RestServer) TaskGetByID() http.HandlerFunc {
 	// - name: id
 	//   in: path
 	//   description: Task ID to fetch
-	//   type: string
+	//   type: integer
 	//   required: true
 	// responses:
 	//   '200':
 	//     description: Task Object
-	//     type: object
 	//     schema:
 	//       "$ref": "#/definitions/models_Task"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -90,11 +101,8 @@ func (s *RestServer) TaskGetByID() http.HandlerFunc {
 		if err != nil {
 			if err == models.ErrNotFound {
 				RenderErrResourceNotFound(w, "task")
-			} else if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpGet))
 			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("TaskGetByID error", "error", err, "error_id", errID)
+				s.renderError(w, "TaskGetByID", err)
 			}
 			return
 		}
@@ -104,24 +112,28 @@ func (s *RestServer) TaskGetByID() http.HandlerFunc {
 
 }
 
-// TaskDeleteByID deletes a task
+// TaskDeleteByID moves a task to the trash
 func (s *RestServer) TaskDeleteByID() http.HandlerFunc {
-	// swagger:operation DELETE /api/tasks/{id} TaskDeleteByID
+	// swagger:operation DELETE /tasks/{id} TaskDeleteByID
 	//
 	// Delete a Task
 	//
-	// Deletes a Task
+	// Moves a Task to the trash, it can be restored until the trash is purged
 	//
 	// ---
 	// parameters:
 	// - name: id
 	//   in: path
 	//   description: Task ID to delete
-	//   type: string
+	//   type: integer
 	//   required: true
 	// responses:
 	//   '204':
 	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
@@ -133,14 +145,14 @@ func (s *RestServer) TaskDeleteByID() http.HandlerFunc {
 		if err != nil {
 			if err == models.ErrNotFound {
 				RenderErrResourceNotFound(w, "task")
-			} else if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpDelete))
 			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("TaskDeleteByID error", "error", err, "error_id", errID)
+				s.renderError(w, "TaskDeleteByID", err)
 			}
 			return
 		}
+		if err := s.webhooks.Publish(ctx, models.EventTaskDeleted, map[string]int{"id": taskID}); err != nil {
+			s.logger.Errorw("TaskDeleteByID webhook error", "error", err)
+		}
 
 		RenderNoContent(w)
 
@@ -150,7 +162,7 @@ func (s *RestServer) TaskDeleteByID() http.HandlerFunc {
 
 // TasksFind finds tasks
 func (s *RestServer) TasksFind() http.HandlerFunc {
-	// swagger:operation GET /api/tasks TasksFind
+	// swagger:operation GET /tasks TasksFind
 	//
 	// Find Tasks
 	//
@@ -161,12 +173,12 @@ func (s *RestServer) TasksFind() http.HandlerFunc {
 	// - name: limit
 	//   in: query
 	//   description: Number of records to return
-	//   type: int
+	//   type: integer
 	//   required: false
 	// - name: offset
 	//   in: query
 	//   description: Offset of records to return
-	//   type: int
+	//   type: integer
 	//   required: false
 	// - name: id
 	//   in: query
@@ -182,21 +194,18 @@ func (s *RestServer) TasksFind() http.HandlerFunc {
 	//   '200':
 	//     description: Task Objects
 	//     schema:
-	//       type: array
-	//       items:
-	//         "$ref": "#/definitions/models_Task"
+	//       "$ref": "#/definitions/models_TaskList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := r.Context()
 
 		tasks, err := s.store.GetAllTasks(ctx)
 		if err != nil {
-			if serr, ok := err.(*models.Error); ok {
-				RenderErrInvalidRequest(w, serr.ErrorForOp(models.ErrorOpFind))
-			} else {
-				errID := RenderErrInternalWithID(w, nil)
-				s.logger.Errorw("TasksFind error", "error", err, "error_id", errID)
-			}
+			s.renderError(w, "TasksFind", err)
 			return
 		}
 
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TemplateSave saves a template
+func (s *RestServer) TemplateSave() http.HandlerFunc {
+
+	// swagger:operation POST /templates TemplateSave
+	//
+	// Create Template
+	//
+	// Creates a task template, recurring ones create their tasks while the server runs. Omit the ID to auto generate.
+	//
+	// ---
+	// parameters:
+	// - name: template
+	//   in: body
+	//   description: Template to Save
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/models_Template"
+	// responses:
+	//   '200':
+	//     description: Template Object
+	//     schema:
+	//       "$ref": "#/definitions/models_Template"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		var template = &models.Template{}
+		if err := DecodeJSON(r.Body, template); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+		templates, err := s.store.TemplateList(ctx)
+		if err != nil {
+			s.renderError(w, "TemplateSave", err)
+			return
+		}
+		if err := template.Validate(templates); err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		templateID, err := s.store.TemplateSave(ctx, template)
+		if err != nil {
+			s.renderError(w, "TemplateSave", err)
+			return
+		}
+		template.ID = templateID
+
+		RenderJSON(w, http.StatusOK, template)
+	}
+
+}
+
+// TemplatesFind lists the templates
+func (s *RestServer) TemplatesFind() http.HandlerFunc {
+
+	// swagger:operation GET /templates TemplatesFind
+	//
+	// Find Templates
+	//
+	// Gets the list of task templates
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Template Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_TemplateList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		templates, err := s.store.TemplateList(ctx)
+		if err != nil {
+			s.renderError(w, "TemplatesFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.TemplateResults{Count: int64(len(templates)), Results: templates})
+	}
+
+}
+
+// TemplateDeleteByID deletes a template
+func (s *RestServer) TemplateDeleteByID() http.HandlerFunc {
+
+	// swagger:operation DELETE /templates/{id} TemplateDeleteByID
+	//
+	// Delete a Template
+	//
+	// Deletes a Template, the tasks created from it are kept
+	//
+	// ---
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: Template ID to delete
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		templateID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+		if err := s.store.TemplateDeleteByID(ctx, templateID); err != nil {
+			s.renderError(w, "TemplateDeleteByID", err)
+			return
+		}
+
+		RenderNoContent(w)
+	}
+
+}
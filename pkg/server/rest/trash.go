@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TaskRestoreByID takes a task out of the trash
+func (s *RestServer) TaskRestoreByID() http.HandlerFunc {
+
+	// swagger:operation POST /tasks/{id}/restore TaskRestoreByID
+	//
+	// Restore a Task
+	//
+	// Takes a Task out of the trash with its pomodoros
+	//
+	// ---
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: Task ID to restore
+	//   type: integer
+	//   required: true
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			RenderErrInvalidRequest(w, err)
+			return
+		}
+
+		if err := s.store.TaskRestoreByID(ctx, taskID); err != nil {
+			if err == models.ErrNotFound {
+				RenderErrResourceNotFound(w, "task")
+			} else {
+				s.renderError(w, "TaskRestoreByID", err)
+			}
+			return
+		}
+
+		RenderNoContent(w)
+	}
+}
+
+// TrashFind lists the tasks in the trash
+func (s *RestServer) TrashFind() http.HandlerFunc {
+
+	// swagger:operation GET /trash TrashFind
+	//
+	// Find Deleted Tasks
+	//
+	// Gets the tasks in the trash, last deleted first
+	//
+	// ---
+	// responses:
+	//   '200':
+	//     description: Task Objects
+	//     schema:
+	//       "$ref": "#/definitions/models_TaskList"
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		tasks, err := s.store.TrashList(r.Context())
+		if err != nil {
+			s.renderError(w, "TrashFind", err)
+			return
+		}
+
+		RenderJSON(w, http.StatusOK, models.Results{Count: int64(len(tasks)), Results: tasks})
+	}
+}
+
+// TrashEmpty permanently deletes the tasks in the trash
+func (s *RestServer) TrashEmpty() http.HandlerFunc {
+
+	// swagger:operation DELETE /trash TrashEmpty
+	//
+	// Empty the Trash
+	//
+	// Permanently deletes the tasks in the trash with their pomodoros and notes
+	//
+	// ---
+	// responses:
+	//   '204':
+	//     description: No Content
+	//   default:
+	//     description: Error
+	//     schema:
+	//       "$ref": "#/definitions/ErrResponse"
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if _, err := s.store.TrashPurge(r.Context(), time.Now()); err != nil {
+			s.renderError(w, "TrashEmpty", err)
+			return
+		}
+
+		RenderNoContent(w)
+	}
+}
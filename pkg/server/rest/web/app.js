@@ -0,0 +1,330 @@
+// pomo web dashboard
+//
+// The dashboard is a client like the command line: it runs the
+// timer of the sessions it starts in the browser and reports them
+// through the REST API (POST /status and POST /tasks/{id}/pomodoros).
+// Sessions started elsewhere are followed through /status/stream.
+"use strict";
+
+const NANOSECOND = 1e-6; // in milliseconds
+const STATES = { 0: "-", 1: "RUNNING", 2: "BREAKING", 3: "COMPLETE", 4: "PAUSED" };
+const RUNNING = 1, BREAKING = 2, COMPLETE = 3, PAUSED = 4;
+const STATUS_INTERVAL = 5000;
+
+const $ = (id) => document.getElementById(id);
+
+// status last received from the stream
+let remote = { state: 0, remaining: 0, count: 0, n_pomodoros: 0, receivedAt: Date.now() };
+// session driven by this browser, null when idle
+let session = null;
+let tasks = [];
+
+async function api(method, path, body) {
+  const res = await fetch(path, {
+    method: method,
+    headers: { "Content-Type": "application/json" },
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  if (!res.ok) {
+    const err = await res.json().catch(() => ({}));
+    throw new Error(err.error || res.statusText);
+  }
+  return res.status === 204 ? null : res.json();
+}
+
+// Session
+
+function sessionStatus() {
+  return {
+    state: session.state,
+    remaining: Math.max(0, Math.round(remainingMs() / NANOSECOND)),
+    count: session.count,
+    n_pomodoros: session.task.n_pomodoros,
+  };
+}
+
+function remainingMs() {
+  if (session.state !== RUNNING) {
+    return session.remaining;
+  }
+  return session.remaining - (Date.now() - session.resumedAt);
+}
+
+function reportStatus() {
+  if (session) {
+    api("POST", "/status", sessionStatus()).catch(showError);
+  }
+}
+
+function startPomodoro() {
+  session.state = RUNNING;
+  session.remaining = session.task.duration * NANOSECOND;
+  session.resumedAt = Date.now();
+  session.pomodoroStart = new Date();
+  reportStatus();
+}
+
+async function endPomodoro() {
+  const pomodoro = { start: session.pomodoroStart.toISOString(), end: new Date().toISOString() };
+  session.count++;
+  session.state = session.count >= session.task.n_pomodoros ? COMPLETE : BREAKING;
+  session.remaining = 0;
+  reportStatus();
+  await api("POST", "/tasks/" + session.task.id + "/pomodoros", pomodoro).catch(showError);
+  notify(session.state === COMPLETE ? "Pomo session has been completed!" : "It is time to take a break!");
+  loadTasks();
+}
+
+function start(task) {
+  if (session && session.state !== COMPLETE) {
+    return;
+  }
+  session = { task: task, count: 0, state: 0, remaining: 0 };
+  startPomodoro();
+  render();
+}
+
+function pause() {
+  if (!session) {
+    return;
+  }
+  if (session.state === RUNNING) {
+    session.remaining = remainingMs();
+    session.state = PAUSED;
+  } else if (session.state === PAUSED) {
+    session.resumedAt = Date.now();
+    session.state = RUNNING;
+  }
+  reportStatus();
+  render();
+}
+
+function skip() {
+  if (!session) {
+    return;
+  }
+  if (session.state === RUNNING || session.state === PAUSED) {
+    endPomodoro();
+  } else if (session.state === BREAKING) {
+    startPomodoro();
+  }
+  render();
+}
+
+function tick() {
+  if (session && session.state === RUNNING && remainingMs() <= 0) {
+    endPomodoro();
+  }
+  render();
+}
+
+function notify(body) {
+  if (window.Notification && Notification.permission === "granted") {
+    new Notification("Pomo", { body: body });
+  }
+}
+
+// Rendering
+
+function formatRemaining(ms) {
+  const total = Math.max(0, Math.round(ms / 1000));
+  const minutes = Math.floor(total / 60);
+  const seconds = total % 60;
+  return String(minutes).padStart(2, "0") + ":" + String(seconds).padStart(2, "0");
+}
+
+function render() {
+  let state, remaining, count, total, duration, title;
+  if (session) {
+    state = session.state;
+    remaining = remainingMs();
+    count = session.count;
+    total = session.task.n_pomodoros;
+    duration = session.task.duration * NANOSECOND;
+    title = session.task.message;
+  } else {
+    state = remote.state;
+    remaining = remote.remaining * NANOSECOND;
+    if (state === RUNNING) {
+      remaining -= Date.now() - remote.receivedAt;
+    }
+    count = remote.count;
+    total = remote.n_pomodoros;
+    duration = 0;
+    title = state ? "Session running elsewhere" : "No session running";
+  }
+
+  $("timer-task").textContent = title;
+  $("timer-state").textContent = STATES[state] || "-";
+  $("timer-state").className = "state " + (STATES[state] || "");
+  $("timer-remaining").textContent = state === RUNNING || state === PAUSED ? formatRemaining(remaining) : "--:--";
+  $("timer-count").textContent = count + "/" + total + " pomodoros";
+  $("timer-progress").style.width = duration > 0 && (state === RUNNING || state === PAUSED)
+    ? (100 * (1 - remaining / duration)).toFixed(1) + "%"
+    : state === COMPLETE ? "100%" : "0";
+
+  const idle = !session || session.state === COMPLETE;
+  $("control-start").disabled = !(session && session.state === BREAKING);
+  $("control-start").textContent = session && session.state === BREAKING ? "Next pomodoro" : "Start";
+  $("control-pause").disabled = idle || session.state === BREAKING;
+  $("control-pause").textContent = session && session.state === PAUSED ? "Resume" : "Pause";
+  $("control-skip").disabled = idle;
+  document.querySelectorAll("#task-list button").forEach((b) => (b.disabled = !idle));
+}
+
+function isToday(date) {
+  const now = new Date();
+  return date.getFullYear() === now.getFullYear() &&
+    date.getMonth() === now.getMonth() &&
+    date.getDate() === now.getDate();
+}
+
+function renderTasks() {
+  const list = $("task-list");
+  list.innerHTML = "";
+  const today = tasks.filter((task) => {
+    const pomodoros = task.pomodoros || [];
+    return pomodoros.length < task.n_pomodoros || pomodoros.some((p) => isToday(new Date(p.start)));
+  });
+  if (today.length === 0) {
+    list.innerHTML = "<li>Nothing planned for today.</li>";
+  }
+  today.forEach((task) => {
+    const done = (task.pomodoros || []).length;
+    const li = document.createElement("li");
+    const info = document.createElement("div");
+    const message = document.createElement("div");
+    message.textContent = task.message;
+    const tags = document.createElement("div");
+    tags.className = "tags";
+    tags.textContent = (task.tags || []).join(", ");
+    const dots = document.createElement("div");
+    dots.className = "dots";
+    for (let i = 0; i < Math.max(done, task.n_pomodoros); i++) {
+      const dot = document.createElement("span");
+      dot.textContent = "●";
+      dot.className = i < done ? "done" : "";
+      dots.appendChild(dot);
+    }
+    info.append(message, tags, dots);
+    const button = document.createElement("button");
+    button.textContent = "Start";
+    button.onclick = () => start(task);
+    li.append(info, button);
+    list.appendChild(li);
+  });
+  render();
+}
+
+function renderWeek() {
+  const days = [];
+  for (let i = 6; i >= 0; i--) {
+    const day = new Date();
+    day.setHours(0, 0, 0, 0);
+    day.setDate(day.getDate() - i);
+    days.push({ date: day, count: 0, minutes: 0 });
+  }
+  tasks.forEach((task) => {
+    (task.pomodoros || []).forEach((p) => {
+      const start = new Date(p.start);
+      const day = days.find((d) => start >= d.date && start - d.date < 24 * 3600 * 1000);
+      if (day) {
+        day.count++;
+        day.minutes += (new Date(p.end) - start) / 60000;
+      }
+    });
+  });
+  const max = Math.max(1, ...days.map((d) => d.count));
+  const svg = $("week-chart");
+  svg.innerHTML = "";
+  const ns = "http://www.w3.org/2000/svg";
+  days.forEach((day, i) => {
+    const height = 120 * day.count / max;
+    const rect = document.createElementNS(ns, "rect");
+    rect.setAttribute("x", 10 + i * 48);
+    rect.setAttribute("y", 130 - height);
+    rect.setAttribute("width", 36);
+    rect.setAttribute("height", height);
+    const label = document.createElementNS(ns, "text");
+    label.setAttribute("x", 28 + i * 48);
+    label.setAttribute("y", 145);
+    label.textContent = day.date.toLocaleDateString(undefined, { weekday: "short" });
+    const value = document.createElementNS(ns, "text");
+    value.setAttribute("x", 28 + i * 48);
+    value.setAttribute("y", 125 - height);
+    value.textContent = day.count || "";
+    svg.append(rect, label, value);
+  });
+  const minutes = Math.round(days.reduce((sum, d) => sum + d.minutes, 0));
+  const count = days.reduce((sum, d) => sum + d.count, 0);
+  $("week-total").textContent = count + " pomodoros, " + Math.floor(minutes / 60) + "h" + (minutes % 60) + "m focused";
+}
+
+async function loadTasks() {
+  try {
+    const list = await api("GET", "/tasks");
+    tasks = list.results || [];
+    renderTasks();
+    renderWeek();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function showError(err) {
+  console.error(err);
+}
+
+// Wiring
+
+function connect() {
+  const stream = new EventSource("/status/stream");
+  stream.addEventListener("open", () => {
+    $("connection").textContent = "online";
+    $("connection").className = "online";
+  });
+  stream.addEventListener("error", () => {
+    $("connection").textContent = "offline";
+    $("connection").className = "offline";
+  });
+  stream.addEventListener("status", (event) => {
+    const status = JSON.parse(event.data);
+    const changed = status.count !== remote.count || status.state !== remote.state;
+    remote = Object.assign(status, { receivedAt: Date.now() });
+    if (changed && !session) {
+      loadTasks();
+    }
+    render();
+  });
+}
+
+$("control-start").onclick = () => session && session.state === BREAKING && startPomodoro();
+$("control-pause").onclick = pause;
+$("control-skip").onclick = skip;
+
+$("create-form").onsubmit = async (event) => {
+  event.preventDefault();
+  const form = event.target;
+  const task = {
+    message: form.message.value,
+    tags: form.tags.value.split(",").map((t) => t.trim()).filter((t) => t),
+    n_pomodoros: parseInt(form.pomodoros.value, 10),
+    duration: parseInt(form.minutes.value, 10) * 60 * 1e9,
+  };
+  try {
+    await api("POST", "/tasks", task);
+    form.reset();
+    loadTasks();
+  } catch (err) {
+    showError(err);
+  }
+};
+
+if (window.Notification && Notification.permission === "default") {
+  Notification.requestPermission();
+}
+
+connect();
+loadTasks();
+setInterval(tick, 1000);
+setInterval(reportStatus, STATUS_INTERVAL);
@@ -0,0 +1,97 @@
+package trash
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"go.uber.org/zap"
+)
+
+// Purger permanently deletes the tasks kept in
+// the trash longer than the retention period
+type Purger struct {
+	store     core.Store
+	retention time.Duration
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a purger checking the trash every configured
+// interval, a retention that is empty or not positive
+// keeps the deleted tasks until the trash is emptied
+func New(store core.Store, config conf.TrashConfig) *Purger {
+	interval, err := time.ParseDuration(config.Interval)
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+	retention, err := time.ParseDuration(config.Retention)
+	if err != nil {
+		retention = 0
+	}
+	return &Purger{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		logger:    zap.S().With("package", "trash"),
+	}
+}
+
+// Start runs the purging loop in the background
+func (p *Purger) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil || p.retention <= 0 {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.loop(p.stop, p.done)
+}
+
+// Stop halts the purging loop
+func (p *Purger) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.stop = nil
+}
+
+func (p *Purger) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Purge(context.Background(), time.Now()); err != nil {
+			p.logger.Errorw("Could not purge the trash", "error", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes the tasks moved to the trash longer than
+// the retention before now and returns their IDs
+func (p *Purger) Purge(ctx context.Context, now time.Time) ([]int, error) {
+	if p.retention <= 0 {
+		return []int{}, nil
+	}
+	purged, err := p.store.TrashPurge(ctx, now.Add(-p.retention))
+	if len(purged) > 0 {
+		p.logger.Debugw("Purged the trash", "tasks", purged)
+	}
+	return purged, err
+}
@@ -0,0 +1,63 @@
+package trash
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestPurge(t *testing.T) {
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	defer store.Close()
+
+	ctx := context.Background()
+	ids := make([]int, 3)
+	for i := range ids {
+		ids[i], err = store.TaskSave(ctx, &models.Task{Message: "task", NPomodoros: 1, Duration: time.Minute})
+		assert.NilError(t, err)
+	}
+	start := time.Now().Add(-time.Hour)
+	assert.NilError(t, store.PomodoroSave(ctx, ids[0], &models.Pomodoro{Start: start, End: start.Add(time.Minute)}))
+	assert.NilError(t, store.TaskDeleteByID(ctx, ids[0]))
+	assert.NilError(t, store.TaskDeleteByID(ctx, ids[1]))
+
+	tasks, err := store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(tasks, 1), "trashed tasks are not listed")
+	trash, err := store.TrashList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(trash, 2))
+	assert.Check(t, trash[0].DeletedAt != nil)
+
+	purged, err := New(store, conf.TrashConfig{Retention: "24h"}).Purge(ctx, time.Now())
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(purged, 0), "within the retention")
+	purged, err = New(store, conf.TrashConfig{}).Purge(ctx, time.Now().AddDate(1, 0, 0))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(purged, 0), "no retention keeps the trash")
+
+	assert.NilError(t, store.TaskRestoreByID(ctx, ids[1]))
+	assert.Equal(t, store.TaskRestoreByID(ctx, ids[2]), models.ErrNotFound, "not in the trash")
+
+	purged, err = New(store, conf.TrashConfig{Retention: "24h"}).Purge(ctx, time.Now().Add(25*time.Hour))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(purged, []int{ids[0]}))
+	_, err = store.PomodoroGetByTaskID(ctx, ids[0])
+	assert.Check(t, is.ErrorIs(err, models.ErrNotFound))
+	check, err := store.Check(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(check.OrphanedPomodoros, 0), "the pomodoros are purged with their task")
+
+	tasks, err = store.GetAllTasks(ctx)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(tasks, 2))
+}
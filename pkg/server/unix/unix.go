@@ -1,25 +1,26 @@
 package unix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"time"
 
 	"github.com/joaorufino/pomo/pkg/conf"
 	"github.com/joaorufino/pomo/pkg/core"
 	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/server/backup"
+	"github.com/joaorufino/pomo/pkg/server/recurring"
+	"github.com/joaorufino/pomo/pkg/server/replication"
+	"github.com/joaorufino/pomo/pkg/server/trash"
+	"github.com/joaorufino/pomo/pkg/server/webhook"
 	serverStore "github.com/joaorufino/pomo/pkg/store"
 	"go.uber.org/zap"
 )
 
-func maybe(err error, logger *zap.SugaredLogger) {
-	if err != nil {
-		logger.Fatalf("Error:%s\n", err)
-		os.Exit(1)
-	}
-}
-
 // UnixServer listens on a Unix domain socket
 // for Pomo status requests
 type UnixServer struct {
@@ -28,151 +29,588 @@ type UnixServer struct {
 	store    core.Store
 	logger   *zap.SugaredLogger
 	status   models.Status
+	controls chan models.Control
+	webhooks *webhook.Dispatcher
+	// creates the tasks of the recurring templates
+	recurring *recurring.Scheduler
+	// purges the tasks kept in the trash too long
+	trash *trash.Purger
+	// backs up the database and rotates the backups
+	backup *backup.Scheduler
 }
 
+// controlQueueSize is the number of controls kept
+// until the client running the session picks them up
+const controlQueueSize = 8
+
 // listens for client requests following models.Protocol
 // cid drives the response
-func (s UnixServer) listen() {
+func (s *UnixServer) listen() {
 	s.logger.Info("Listening")
 	for s.running {
-		buf := make([]byte, 1024)
 		conn, err := s.listener.Accept()
-		maybe(err, s.logger)
-		defer conn.Close()
-		n, _ := conn.Read(buf)
-
-		//data was received
-		if n != 0 {
-			s.logger.Debugf("Incoming request")
-			message := models.Protocol{}
-
-			//Unmarshal to get CommandID
-			err := json.Unmarshal(buf[0:n], &message)
-			maybe(err, s.logger)
-
-			switch message.Cid {
-			//get server status
-			case models.Cmd_GetServerStatus:
-				s.logger.Debug("Incoming status request")
-				_ = s.sendResponse(message.Cid, s.status, conn)
-
-			//get all tasks
-			case models.Cmd_GetList:
-				s.logger.Debug("Incoming task list request")
-				tasks, err := s.store.GetAllTasks(nil)
-				maybe(err, s.logger)
-				_ = s.sendResponse(message.Cid, tasks, conn)
-
-			//create a task return
-			case models.Cmd_CreateTask:
-				s.createTask(buf[0:n], conn)
-			//delete a task by id
-			case models.Cmd_DeleteTask:
-				s.deleteTask(buf[0:n], conn)
-			//get a task by ID
-			case models.Cmd_GetTask:
-				s.getTask(buf[0:n], conn)
-
-			//get a pomodoro by taskID
-			case models.Cmd_CreatePomodoro:
-				s.createPomodoro(buf[0:n], conn)
-
-			//update server status
-			case models.Cmd_UpdateStatus:
-				s.updateStatus(buf[0:n], conn)
+		if err != nil {
+			if s.running {
+				s.logger.Errorf("Could not accept a connection: %s", err)
 			}
+			continue
 		}
+		s.serve(conn)
+		conn.Close()
+	}
+}
+
+// serve answers a single request, a failed request is
+// answered with its error and never stops the server
+func (s *UnixServer) serve(conn net.Conn) {
+	var raw json.RawMessage
+	err := json.NewDecoder(conn).Decode(&raw)
+	//no data was received
+	if err == io.EOF {
+		return
+	}
+	s.logger.Debugf("Incoming request")
+	message := models.Protocol{}
+
+	//Unmarshal to get CommandID
+	if err == nil {
+		err = json.Unmarshal(raw, &message)
+	}
+	if err != nil {
+		s.sendError(message.Cid, models.NewError(models.ErrorTypeInvalid, "malformed request: %s", err), conn)
+		return
+	}
+	payload, err := s.handle(message.Cid, raw)
+	if err != nil {
+		s.sendError(message.Cid, err, conn)
+		return
+	}
+	_ = s.sendResponse(message.Cid, payload, conn)
+}
+
+// handle runs the command of the request and returns the payload
+// of the response, an empty string when there is nothing to return
+func (s *UnixServer) handle(cid models.CmdID, buffer []byte) (interface{}, error) {
+	switch cid {
+	//get server status
+	case models.Cmd_GetServerStatus:
+		s.logger.Debug("Incoming status request")
+		return s.status, nil
+
+	//get all tasks
+	case models.Cmd_GetList:
+		s.logger.Debug("Incoming task list request")
+		return s.store.GetAllTasks(nil)
+
+	//create a task return
+	case models.Cmd_CreateTask:
+		return s.createTask(buffer)
+	//delete a task by id
+	case models.Cmd_DeleteTask:
+		return s.deleteTask(buffer)
+	//get a task by ID
+	case models.Cmd_GetTask:
+		return s.getTask(buffer)
+	//get a task by ID with its pomodoros reset for a new run
+	case models.Cmd_StartTask:
+		return s.startTask(buffer)
+
+	//get a pomodoro by taskID
+	case models.Cmd_CreatePomodoro:
+		return s.createPomodoro(buffer)
+
+	//update server status
+	case models.Cmd_UpdateStatus:
+		return s.updateStatus(buffer)
+
+	//queue a control for the running session
+	case models.Cmd_SendControl:
+		return s.sendControl(buffer)
+	//hand the next control to the running session
+	case models.Cmd_GetControl:
+		return s.getControl()
+
+	//create a goal
+	case models.Cmd_CreateGoal:
+		return s.createGoal(buffer)
+	//get all goals
+	case models.Cmd_GetGoals:
+		s.logger.Debug("Incoming goal list request")
+		return s.store.GoalList(nil)
+	//delete a goal by id
+	case models.Cmd_DeleteGoal:
+		return s.deleteGoal(buffer)
+
+	//create a project
+	case models.Cmd_CreateProject:
+		return s.createProject(buffer)
+	//get all projects
+	case models.Cmd_GetProjects:
+		s.logger.Debug("Incoming project list request")
+		return s.store.ProjectList(nil)
+	//archive a project and its subprojects
+	case models.Cmd_ArchiveProject:
+		return s.archiveProject(buffer)
+
+	//get all tags
+	case models.Cmd_GetTags:
+		s.logger.Debug("Incoming tag list request")
+		return s.store.TagList(nil)
+	//set the color of a tag
+	case models.Cmd_SetTagColor:
+		return s.setTagColor(buffer)
+	//rename or merge tags
+	case models.Cmd_RenameTags:
+		return s.renameTags(buffer)
+
+	//create a task template
+	case models.Cmd_CreateTemplate:
+		return s.createTemplate(buffer)
+	//get all templates
+	case models.Cmd_GetTemplates:
+		s.logger.Debug("Incoming template list request")
+		return s.store.TemplateList(nil)
+	//delete a template by id
+	case models.Cmd_DeleteTemplate:
+		return s.deleteTemplate(buffer)
+
+	//add a note to a task or one of its pomodoros
+	case models.Cmd_AddNote:
+		return s.addNote(buffer)
+
+	//get, correct or delete a single pomodoro
+	case models.Cmd_GetPomodoro:
+		return s.getPomodoro(buffer)
+	case models.Cmd_UpdatePomodoro:
+		return s.updatePomodoro(buffer)
+	case models.Cmd_DeletePomodoro:
+		return s.deletePomodoro(buffer)
+	//list the pomodoros of a task or a time range
+	case models.Cmd_GetPomodoros:
+		return s.getPomodoros(buffer)
+
+	//take a task out of the trash
+	case models.Cmd_RestoreTask:
+		return s.restoreTask(buffer)
+	//list the tasks in the trash
+	case models.Cmd_GetTrash:
+		s.logger.Debug("Incoming trash list request")
+		return s.store.TrashList(nil)
+	//permanently delete the tasks in the trash
+	case models.Cmd_EmptyTrash:
+		s.logger.Debug("Incoming empty trash request")
+		_, err := s.store.TrashPurge(nil, time.Now())
+		return "", err
+
+	//exchange the tasks with a remote server
+	case models.Cmd_Sync:
+		return s.sync(buffer)
 	}
+	return nil, models.NewError(models.ErrorTypeInvalid, "unknown command %d", cid)
 }
-func (s UnixServer) deleteTask(buffer []byte, conn net.Conn) {
+func (s *UnixServer) deleteTask(buffer []byte) (interface{}, error) {
 	s.logger.Debug("Incoming delete task request")
-	payload := models.Protocol{Payload: 0}
-	json.Unmarshal(buffer, &payload)
-	taskId, ok := payload.Payload.(float64)
-	valid(ok, s.logger, payload.Payload, taskId)
+	taskId, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
 
 	s.logger.Debugf("TaskId:%d", taskId)
-	err := s.store.TaskDeleteByID(nil, int(taskId))
-	maybe(err, s.logger)
-	_ = s.sendResponse(payload.Cid, "", conn)
+	if err := s.store.TaskDeleteByID(nil, taskId); err != nil {
+		return nil, taskError(taskId, err)
+	}
+	s.publish(models.EventTaskDeleted, map[string]int{"id": taskId})
+	return "", nil
 }
-func (s UnixServer) getTask(buffer []byte, conn net.Conn) {
+func (s *UnixServer) restoreTask(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming restore task request")
+	taskID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.TaskRestoreByID(nil, taskID)
+	if err == models.ErrNotFound {
+		return nil, models.NewError(models.ErrorTypeNotFound, "task %d is not in the trash", taskID)
+	}
+	return "", err
+}
+func (s *UnixServer) sync(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming sync request")
+	remote := &models.SyncRemote{}
+	if err := decode(buffer, remote); err != nil {
+		return nil, err
+	}
+	return replication.Sync(context.Background(), s.store, remote.Remote)
+}
+func (s *UnixServer) getTask(buffer []byte) (interface{}, error) {
 	s.logger.Debug("Incoming get task request")
-	payload := models.Protocol{Payload: 0}
-	json.Unmarshal(buffer, &payload)
-	taskId, ok := payload.Payload.(float64)
-	valid(ok, s.logger, payload.Payload, taskId)
+	taskId, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
 
 	s.logger.Debugf("TaskId:%d", taskId)
-	var task *models.Task
-	task, err := s.store.TaskGetByID(nil, int(taskId))
-	maybe(err, s.logger)
-	err = s.store.PomodoroDeleteByTaskID(nil, int(taskId))
-	maybe(err, s.logger)
+	task, err := s.store.TaskGetByID(nil, taskId)
+	if err == nil {
+		task.Pomodoros, err = s.store.PomodoroGetByTaskID(nil, taskId)
+	}
+	if err != nil {
+		return nil, taskError(taskId, err)
+	}
+	return task, nil
+}
+func (s *UnixServer) startTask(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming start task request")
+	taskId, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.store.TaskGetByID(nil, taskId)
+	if err != nil {
+		return nil, taskError(taskId, err)
+	}
+	if err := s.store.PomodoroDeleteByTaskID(nil, taskId); err != nil {
+		return nil, err
+	}
 	task.Pomodoros = []*models.Pomodoro{}
-	_ = s.sendResponse(payload.Cid, task, conn)
+	return task, nil
 }
-func (s UnixServer) createPomodoro(buffer []byte, conn net.Conn) {
+func (s *UnixServer) createPomodoro(buffer []byte) (interface{}, error) {
 	s.logger.Debug("Incoming create pomodoro request")
-	payload := models.Protocol{Payload: &models.PomodoroWithID{}}
-	json.Unmarshal(buffer, &payload)
-	pomodoro, ok := payload.Payload.(*models.PomodoroWithID)
-	valid(ok, s.logger, payload.Payload, pomodoro)
+	pomodoro := &models.PomodoroWithID{}
+	if err := decode(buffer, pomodoro); err != nil {
+		return nil, err
+	}
+
+	if err := pomodoro.Pomodoro.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	if err := s.store.PomodoroSave(nil, pomodoro.TaskID, &pomodoro.Pomodoro); err != nil {
+		return nil, taskError(pomodoro.TaskID, err)
+	}
+	s.publish(models.EventPomodoroCompleted, pomodoro)
+	return "", nil
+}
+func (s *UnixServer) getPomodoros(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming pomodoro list request")
+	query := &models.PomodoroQuery{}
+	if err := decode(buffer, query); err != nil {
+		return nil, err
+	}
+	return s.store.PomodoroList(nil, *query)
+}
+func (s *UnixServer) getPomodoro(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming get pomodoro request")
+	pomodoroID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	pomodoro, err := s.store.PomodoroGetByID(nil, pomodoroID)
+	if err == models.ErrNotFound {
+		return nil, models.NewError(models.ErrorTypeNotFound, "pomodoro %d does not exist", pomodoroID)
+	}
+	return pomodoro, err
+}
+func (s *UnixServer) updatePomodoro(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming update pomodoro request")
+	pomodoro := &models.Pomodoro{}
+	if err := decode(buffer, pomodoro); err != nil {
+		return nil, err
+	}
 
-	err := s.store.PomodoroSave(nil, pomodoro.TaskID, &pomodoro.Pomodoro)
-	maybe(err, s.logger)
-	_ = s.sendResponse(payload.Cid, err.Error(), conn)
+	if err := pomodoro.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	err := s.store.PomodoroUpdate(nil, pomodoro)
+	if err == models.ErrNotFound {
+		return nil, models.NewError(models.ErrorTypeNotFound, "pomodoro %d does not exist", pomodoro.ID)
+	}
+	return "", err
 }
-func (s UnixServer) createTask(buffer []byte, conn net.Conn) {
+func (s *UnixServer) deletePomodoro(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming delete pomodoro request")
+	pomodoroID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.PomodoroDeleteByID(nil, pomodoroID)
+	if err == models.ErrNotFound {
+		return nil, models.NewError(models.ErrorTypeNotFound, "pomodoro %d does not exist", pomodoroID)
+	}
+	return "", err
+}
+func (s *UnixServer) createTask(buffer []byte) (interface{}, error) {
 	s.logger.Debug("Incoming create task request")
-	var taskId int
-	payload := models.Protocol{Payload: &models.Task{}}
-	json.Unmarshal(buffer, &payload)
-	task, ok := payload.Payload.(*models.Task)
-	valid(ok, s.logger, payload.Payload, task)
+	task := &models.Task{}
+	if err := decode(buffer, task); err != nil {
+		return nil, err
+	}
 
 	taskId, err := s.store.TaskSave(nil, task)
-	maybe(err, s.logger)
-	_ = s.sendResponse(payload.Cid, taskId, conn)
+	if err != nil {
+		return nil, err
+	}
+	task.ID = taskId
+	s.publish(models.EventTaskCreated, task)
+	return taskId, nil
 }
-func (s UnixServer) updateStatus(buffer []byte, conn net.Conn) {
+func (s *UnixServer) updateStatus(buffer []byte) (interface{}, error) {
 	s.logger.Debug("Incoming update status request")
 
-	payload := models.Protocol{Payload: &models.Status{}}
-	json.Unmarshal(buffer, &payload)
-
-	status, ok := payload.Payload.(*models.Status)
-	valid(ok, s.logger, payload.Payload, status)
+	status := &models.Status{}
+	if err := decode(buffer, status); err != nil {
+		return nil, err
+	}
 
+	prev := s.status
 	s.status = *status
-	_ = s.sendResponse(payload.Cid, "", conn)
+	if !status.State.Active() {
+		s.dropControls()
+	}
+	if err := s.webhooks.PublishStatus(context.Background(), prev, s.status); err != nil {
+		s.logger.Errorf("Could not publish the status events: %s", err)
+	}
+	return "", nil
+}
+
+func (s *UnixServer) sendControl(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming send control request")
+
+	control := &models.Control{}
+	if err := decode(buffer, control); err != nil {
+		return nil, err
+	}
+
+	if err := control.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	if !s.status.State.Active() {
+		return nil, models.NewError(models.ErrorTypeInvalid, "no session is running")
+	}
+	select {
+	case s.controls <- *control:
+		return "", nil
+	default:
+		return nil, models.NewError(models.ErrorTypeInvalid, "too many pending controls")
+	}
+}
+
+// dropControls empties the queue once the session has ended,
+// the controls sent too late never reach the next session
+func (s *UnixServer) dropControls() {
+	for {
+		select {
+		case <-s.controls:
+		default:
+			return
+		}
+	}
+}
+
+func (s *UnixServer) getControl() (interface{}, error) {
+	s.logger.Debug("Incoming get control request")
+	select {
+	case control := <-s.controls:
+		return control, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (s *UnixServer) createGoal(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming create goal request")
+	goal := &models.Goal{}
+	if err := decode(buffer, goal); err != nil {
+		return nil, err
+	}
+
+	if err := goal.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	return s.store.GoalSave(nil, goal)
+}
+func (s *UnixServer) deleteGoal(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming delete goal request")
+	goalID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return "", s.store.GoalDeleteByID(nil, goalID)
+}
+
+func (s *UnixServer) createProject(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming create project request")
+	project := &models.Project{}
+	if err := decode(buffer, project); err != nil {
+		return nil, err
+	}
+
+	projects, err := s.store.ProjectList(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Validate(projects); err != nil {
+		return nil, invalid(err)
+	}
+	return s.store.ProjectSave(nil, project)
+}
+func (s *UnixServer) archiveProject(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming archive project request")
+	projectID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.store.ProjectList(nil)
+	if err != nil {
+		return nil, err
+	}
+	if projects.ByID(projectID) == nil {
+		return nil, models.NewError(models.ErrorTypeNotFound, "project not found")
+	}
+	for id := range projects.Subtree(projectID) {
+		if err := s.store.ProjectArchiveByID(nil, id); err != nil {
+			return nil, err
+		}
+	}
+	return "", nil
+}
+
+func (s *UnixServer) setTagColor(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming set tag color request")
+	tag := &models.Tag{}
+	if err := decode(buffer, tag); err != nil {
+		return nil, err
+	}
+
+	if err := tag.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	return "", s.store.TagSave(nil, tag)
+}
+
+func (s *UnixServer) renameTags(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming rename tags request")
+	rename := &models.TagRename{}
+	if err := decode(buffer, rename); err != nil {
+		return nil, err
+	}
+
+	if err := rename.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+	return "", s.store.TagRename(nil, *rename)
+}
+
+func (s *UnixServer) createTemplate(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming create template request")
+	template := &models.Template{}
+	if err := decode(buffer, template); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.store.TemplateList(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Validate(templates); err != nil {
+		return nil, invalid(err)
+	}
+	return s.store.TemplateSave(nil, template)
+}
+
+func (s *UnixServer) deleteTemplate(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming delete template request")
+	templateID, err := decodeID(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return "", s.store.TemplateDeleteByID(nil, templateID)
+}
+
+func (s *UnixServer) addNote(buffer []byte) (interface{}, error) {
+	s.logger.Debug("Incoming add note request")
+	note := &models.NoteWithID{}
+	if err := decode(buffer, note); err != nil {
+		return nil, err
+	}
+
+	task, err := s.store.TaskGetByID(nil, note.TaskID)
+	if err != nil {
+		return nil, taskError(note.TaskID, err)
+	}
+	task.Pomodoros, err = s.store.PomodoroGetByTaskID(nil, note.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := note.Note.Validate(task); err != nil {
+		return nil, invalid(err)
+	}
+	if note.Note.Time.IsZero() {
+		note.Note.Time = time.Now()
+	}
+	return "", s.store.NoteSave(nil, note.TaskID, &note.Note)
+}
+
+// publish queues an event for the webhook endpoints,
+// failures are logged and never break the request
+func (s *UnixServer) publish(eventType models.EventType, data interface{}) {
+	if err := s.webhooks.Publish(context.Background(), eventType, data); err != nil {
+		s.logger.Errorf("Could not publish %s event: %s", eventType, err)
+	}
 }
 
 // makeRequest sends a message to the server
 // using the protocol structure
-func (s UnixServer) sendResponse(cid models.CmdID, payload interface{}, conn net.Conn) error {
+func (s *UnixServer) sendResponse(cid models.CmdID, payload interface{}, conn net.Conn) error {
 	raw, err := json.Marshal(&models.Protocol{Cid: cid, Payload: payload})
-	maybe(err, s.logger)
+	if err != nil {
+		s.sendError(cid, err, conn)
+		return err
+	}
 	s.logger.Debugf("writing tasks:%s", string(raw))
+	_, err = conn.Write(raw)
+	return err
+}
+
+// sendError answers a failed request with its typed error,
+// the unexpected ones are logged
+func (s *UnixServer) sendError(cid models.CmdID, err error, conn net.Conn) {
+	response := &models.Error{Type: models.TypeOf(err), Err: err}
+	if response.Type == models.ErrorTypeInternal || response.Type == models.ErrorTypeQuery {
+		s.logger.Errorw("Request failed", "cid", cid, "error", err)
+	}
+	raw, _ := json.Marshal(&models.Protocol{Cid: cid, Error: response})
 	conn.Write(raw)
-	return nil
 }
 
 // Starts the server
-func (s UnixServer) Start() {
+func (s *UnixServer) Start() {
 	s.running = true
+	s.webhooks.Start()
+	s.recurring.Start()
+	s.trash.Start()
+	s.backup.Start()
 	s.listen()
 }
 
 // Stops the server
-func (s UnixServer) Stop() {
+func (s *UnixServer) Stop() {
 	s.running = false
+	s.backup.Stop()
+	s.trash.Stop()
+	s.recurring.Stop()
+	s.webhooks.Stop()
 	s.listener.Close()
 	s.store.Close()
 }
 
 // Initializes the server structure
-func (s UnixServer) Init(config *conf.Config) (*UnixServer, error) {
+func (s *UnixServer) Init(config *conf.Config) (*UnixServer, error) {
 	socketPath := config.Server.UnixSocket
 	if _, err := os.Stat(socketPath); err == nil {
 		_, err := net.Dial("unix", socketPath)
@@ -185,7 +623,13 @@ func (s UnixServer) Init(config *conf.Config) (*UnixServer, error) {
 		}
 	}
 	store, err := serverStore.NewStore(k)
-	maybe(err, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.InitDB(); err != nil {
+		store.Close()
+		return nil, err
+	}
 
 	//open the socket
 	listener, err := net.Listen("unix", socketPath)
@@ -197,14 +641,42 @@ func (s UnixServer) Init(config *conf.Config) (*UnixServer, error) {
 		logger:   zap.S().With("package", "server"),
 		store:    store,
 		status:   models.Status{},
+		controls: make(chan models.Control, controlQueueSize),
+		webhooks: webhook.New(store, config.Webhooks),
 	}
+	server.recurring = recurring.New(store, server.webhooks, config.Recurring)
+	server.trash = trash.New(store, config.Trash)
+	server.backup = backup.New(store, config.Backup)
 
 	return server, nil
 
 }
 
-func valid(ok bool, logger *zap.SugaredLogger, expected interface{}, received interface{}) {
-	if !ok {
-		logger.Fatalf(models.ErrWrongDataType, expected, received)
+// decode reads the payload of the request into payload
+func decode(buffer []byte, payload interface{}) error {
+	message := models.Protocol{Payload: payload}
+	if err := json.Unmarshal(buffer, &message); err != nil {
+		return models.NewError(models.ErrorTypeInvalid, "wrong data type: %s", err)
+	}
+	return nil
+}
+
+// decodeID reads the ID sent as the payload of the request
+func decodeID(buffer []byte) (int, error) {
+	var id int
+	err := decode(buffer, &id)
+	return id, err
+}
+
+// taskError names the task that does not exist
+func taskError(taskID int, err error) error {
+	if err == models.ErrNotFound {
+		return models.NewError(models.ErrorTypeNotFound, "task %d does not exist", taskID)
 	}
+	return err
+}
+
+// invalid marks the error of a validation
+func invalid(err error) error {
+	return &models.Error{Type: models.ErrorTypeInvalid, Err: err}
 }
@@ -0,0 +1,67 @@
+package unix
+
+import (
+	"encoding/json"
+	"net"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestServeLargeRequests(t *testing.T) {
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	t.Cleanup(func() { store.Close() })
+	assert.NilError(t, store.InitDB())
+	s := &UnixServer{store: store, logger: zap.NewNop().Sugar()}
+
+	request := func(cid models.CmdID, payload, response interface{}) *models.Error {
+		t.Helper()
+		client, server := net.Pipe()
+		defer client.Close()
+		go func() {
+			s.serve(server)
+			server.Close()
+		}()
+		assert.NilError(t, json.NewEncoder(client).Encode(&models.Protocol{Cid: cid, Payload: payload}))
+		message := models.Protocol{Payload: response}
+		assert.NilError(t, json.NewDecoder(client).Decode(&message))
+		return message.Error
+	}
+
+	// far more than a single read of the socket
+	long := strings.Repeat("a long message ", 1000)
+	for i := 0; i < 3; i++ {
+		var taskID int
+		task := &models.Task{Message: long, NPomodoros: 1, Duration: 25 * time.Minute}
+		assert.Assert(t, request(models.Cmd_CreateTask, task, &taskID) == nil)
+		assert.Equal(t, taskID, i+1)
+	}
+	list := models.List{}
+	assert.Assert(t, request(models.Cmd_GetList, nil, &list) == nil)
+	assert.Assert(t, is.Len(list, 3))
+	for _, task := range list {
+		assert.Check(t, is.Equal(task.Message, long))
+	}
+
+	// a control left when the session ends is dropped
+	s.controls = make(chan models.Control, controlQueueSize)
+	assert.Assert(t, request(models.Cmd_UpdateStatus, &models.Status{State: models.RUNNING}, nil) == nil)
+	assert.Assert(t, request(models.Cmd_SendControl, &models.Control{Action: models.ControlSkip}, nil) == nil)
+	assert.Assert(t, request(models.Cmd_UpdateStatus, &models.Status{State: models.COMPLETE}, nil) == nil)
+	assert.Assert(t, request(models.Cmd_UpdateStatus, &models.Status{State: models.RUNNING}, nil) == nil)
+	var control *models.Control
+	assert.Assert(t, request(models.Cmd_GetControl, nil, &control) == nil)
+	assert.Check(t, control == nil)
+
+	failed := request(models.CmdID(-1), nil, nil)
+	assert.Assert(t, failed != nil)
+	assert.Check(t, is.Equal(failed.Type, models.ErrorTypeInvalid))
+}
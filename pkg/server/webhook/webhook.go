@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the request body
+	SignatureHeader = "X-Pomo-Signature"
+	// EventHeader carries the event type
+	EventHeader = "X-Pomo-Event"
+	// DeliveryHeader carries the event id, stable across retries
+	DeliveryHeader = "X-Pomo-Delivery"
+)
+
+// Dispatcher posts server events to the configured
+// webhook endpoints. Events are queued in the store
+// first so that they survive restarts and are retried
+// with an exponential backoff until delivered.
+type Dispatcher struct {
+	store     core.Store
+	endpoints map[string]models.WebhookEndpoint
+	client    *http.Client
+	attempts  int
+	backoff   time.Duration
+	interval  time.Duration
+	// time the failed deliveries are kept, zero keeps them
+	retention time.Duration
+	logger    *zap.SugaredLogger
+
+	mu   sync.Mutex
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a dispatcher for the given configuration.
+// A dispatcher without endpoints silently drops every event.
+func New(store core.Store, config conf.WebhooksConfig) *Dispatcher {
+	d := &Dispatcher{
+		store:     store,
+		endpoints: make(map[string]models.WebhookEndpoint),
+		attempts:  config.Attempts,
+		backoff:   parseDuration(config.Backoff, 30*time.Second),
+		interval:  parseDuration(config.Interval, 10*time.Second),
+		retention: parseDuration(config.Retention, 0),
+		client: &http.Client{
+			Timeout: parseDuration(config.Timeout, 10*time.Second),
+		},
+		logger: zap.S().With("package", "webhook"),
+		wake:   make(chan struct{}, 1),
+	}
+	if d.attempts <= 0 {
+		d.attempts = 1
+	}
+	for _, endpoint := range config.Endpoints {
+		name := endpoint.Name
+		if name == "" {
+			name = endpoint.URL
+		}
+		endpoint.Name = name
+		d.endpoints[name] = endpoint
+	}
+	return d
+}
+
+// Endpoints returns the configured endpoints
+func (d *Dispatcher) Endpoints() []models.WebhookEndpoint {
+	endpoints := []models.WebhookEndpoint{}
+	for _, endpoint := range d.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+	return endpoints
+}
+
+// NewEvent builds an event of the given type
+// with data marshalled as its JSON payload
+func NewEvent(eventType models.EventType, data interface{}) (models.Event, error) {
+	event := models.Event{
+		ID:   xid.New().String(),
+		Type: eventType,
+		Time: time.Now(),
+	}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return event, err
+		}
+		event.Data = raw
+	}
+	return event, nil
+}
+
+// Publish queues an event for every endpoint
+// subscribed to its type and wakes up the
+// delivery loop.
+func (d *Dispatcher) Publish(ctx context.Context, eventType models.EventType, data interface{}) error {
+	if d == nil || len(d.endpoints) == 0 {
+		return nil
+	}
+	event, err := NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range d.endpoints {
+		if !endpoint.Accepts(eventType) {
+			continue
+		}
+		_, err := d.store.WebhookDeliverySave(ctx, &models.WebhookDelivery{
+			Endpoint:    endpoint.Name,
+			Event:       event,
+			NextAttempt: event.Time,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// PublishStatus publishes the session events implied by a
+// status change, the completed pomodoros are published
+// with the pomodoro when the server saves it
+func (d *Dispatcher) PublishStatus(ctx context.Context, prev, next models.Status) error {
+	for _, eventType := range models.StatusEvents(prev, next) {
+		if eventType == models.EventPomodoroCompleted {
+			continue
+		}
+		if err := d.Publish(ctx, eventType, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start runs the delivery loop in the background
+func (d *Dispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stop != nil || len(d.endpoints) == 0 {
+		return
+	}
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+	go d.loop(d.stop, d.done)
+}
+
+// Stop halts the delivery loop, pending
+// deliveries stay queued in the store
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stop == nil {
+		return
+	}
+	close(d.stop)
+	<-d.done
+	d.stop = nil
+}
+
+func (d *Dispatcher) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Flush(context.Background()); err != nil {
+			d.logger.Errorw("Could not flush webhook deliveries", "error", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush purges the deliveries failed longer than the
+// retention and attempts every queued delivery that is due
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	if d.retention > 0 {
+		if _, err := d.store.WebhookDeliveryPurge(ctx, time.Now().Add(-d.retention)); err != nil {
+			return err
+		}
+	}
+	deliveries, err := d.store.WebhookDeliveryList(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, delivery := range deliveries {
+		if delivery.Failed || delivery.NextAttempt.After(now) {
+			continue
+		}
+		if err := d.attempt(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attempt makes a single delivery attempt and
+// records the outcome in the store
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.Attempts++
+	endpoint, ok := d.endpoints[delivery.Endpoint]
+	if !ok {
+		delivery.LastError = "endpoint is no longer configured"
+		delivery.Failed = true
+		delivery.NextAttempt = time.Now()
+		return d.store.WebhookDeliveryUpdate(ctx, delivery)
+	}
+	err := d.Deliver(ctx, endpoint, delivery.Event)
+	if err == nil {
+		return d.store.WebhookDeliveryDeleteByID(ctx, delivery.ID)
+	}
+	d.logger.Debugw("Webhook delivery failed", "endpoint", endpoint.Name, "attempt", delivery.Attempts, "error", err)
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.attempts {
+		delivery.Failed = true
+		delivery.NextAttempt = time.Now()
+	} else {
+		delivery.NextAttempt = time.Now().Add(d.backoff << (delivery.Attempts - 1))
+	}
+	return d.store.WebhookDeliveryUpdate(ctx, delivery)
+}
+
+// Deliver posts a single event to an endpoint,
+// any non 2xx response is reported as an error
+func (d *Dispatcher) Deliver(ctx context.Context, endpoint models.WebhookEndpoint, event models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID)
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value
+// of body for the given secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/conf"
+	"github.com/joaorufino/pomo/pkg/core"
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/joaorufino/pomo/pkg/store/sqlite"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	events   []models.Event
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	event := models.Event{}
+	_ = json.Unmarshal(body, &event)
+	r.events = append(r.events, event)
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newTestStore(t *testing.T) core.Store {
+	store, err := sqlite.NewStore(path.Join(t.TempDir(), "pomo.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, store.InitDB())
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestDispatcherDeliversSignedEvents(t *testing.T) {
+	rx := &receiver{}
+	srv := httptest.NewServer(rx)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	d := New(store, conf.WebhooksConfig{
+		Endpoints: []models.WebhookEndpoint{
+			{Name: "dashboard", URL: srv.URL, Secret: "s3cr3t", Events: []string{"task.created"}},
+		},
+	})
+	ctx := context.Background()
+	assert.NilError(t, d.Publish(ctx, models.EventTaskCreated, models.Task{ID: 1, Message: "write tests"}))
+	// not subscribed
+	assert.NilError(t, d.Publish(ctx, models.EventTaskDeleted, nil))
+	assert.NilError(t, d.Flush(ctx))
+
+	assert.Assert(t, is.Len(rx.events, 1))
+	assert.Equal(t, rx.events[0].Type, models.EventTaskCreated)
+	assert.Equal(t, rx.headers[0].Get(EventHeader), string(models.EventTaskCreated))
+	assert.Assert(t, Verify("s3cr3t", rx.bodies[0], rx.headers[0].Get(SignatureHeader)))
+
+	deliveries, err := store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 0))
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	rx := &receiver{failures: 1}
+	srv := httptest.NewServer(rx)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	d := New(store, conf.WebhooksConfig{
+		Endpoints: []models.WebhookEndpoint{{URL: srv.URL}},
+		Attempts:  2,
+		Backoff:   "1ns",
+	})
+	ctx := context.Background()
+	assert.NilError(t, d.Publish(ctx, models.EventSessionCompleted, models.Status{State: models.COMPLETE}))
+
+	assert.NilError(t, d.Flush(ctx))
+	deliveries, err := store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 1))
+	assert.Equal(t, deliveries[0].Attempts, 1)
+	assert.Assert(t, !deliveries[0].Failed)
+	assert.Assert(t, deliveries[0].LastError != "")
+
+	assert.NilError(t, d.Flush(ctx))
+	assert.Assert(t, is.Len(rx.events, 1))
+	assert.Equal(t, rx.headers[0].Get(DeliveryHeader), deliveries[0].Event.ID)
+	deliveries, err = store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 0))
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	rx := &receiver{failures: 10}
+	srv := httptest.NewServer(rx)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	d := New(store, conf.WebhooksConfig{
+		Endpoints: []models.WebhookEndpoint{{URL: srv.URL}},
+		Attempts:  1,
+	})
+	ctx := context.Background()
+	assert.NilError(t, d.Publish(ctx, models.EventPomodoroStarted, nil))
+	assert.NilError(t, d.Flush(ctx))
+	assert.NilError(t, d.Flush(ctx))
+
+	deliveries, err := store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 1))
+	assert.Assert(t, deliveries[0].Failed)
+	assert.Equal(t, deliveries[0].Attempts, 1)
+}
+
+func TestDispatcherPurgesFailedDeliveries(t *testing.T) {
+	store := newTestStore(t)
+	d := New(store, conf.WebhooksConfig{Retention: "1h"})
+	ctx := context.Background()
+	event, err := NewEvent(models.EventPing, nil)
+	assert.NilError(t, err)
+	now := time.Now()
+	for _, delivery := range []*models.WebhookDelivery{
+		{Endpoint: "old", Event: event, Attempts: 5, NextAttempt: now.Add(-2 * time.Hour), Failed: true},
+		{Endpoint: "recent", Event: event, Attempts: 5, NextAttempt: now.Add(-time.Minute), Failed: true},
+		// retried later, not failed yet
+		{Endpoint: "pending", Event: event, Attempts: 1, NextAttempt: now.Add(time.Hour)},
+	} {
+		_, err := store.WebhookDeliverySave(ctx, delivery)
+		assert.NilError(t, err)
+	}
+
+	assert.NilError(t, d.Flush(ctx))
+	deliveries, err := store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	endpoints := []string{}
+	for _, delivery := range deliveries {
+		endpoints = append(endpoints, delivery.Endpoint)
+	}
+	assert.DeepEqual(t, endpoints, []string{"recent", "pending"})
+
+	// without a retention the failed deliveries are kept
+	assert.NilError(t, New(store, conf.WebhooksConfig{}).Flush(ctx))
+	deliveries, err = store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 2))
+}
+
+func TestPublishStatus(t *testing.T) {
+	store := newTestStore(t)
+	d := New(store, conf.WebhooksConfig{Endpoints: []models.WebhookEndpoint{{URL: "http://localhost"}}})
+	ctx := context.Background()
+	// the server publishes the completed pomodoro when it is saved
+	running := models.Status{State: models.RUNNING, Count: 1, NPomodoros: 2}
+	assert.NilError(t, d.PublishStatus(ctx, running, models.Status{State: models.COMPLETE, Count: 2, NPomodoros: 2}))
+
+	deliveries, err := store.WebhookDeliveryList(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deliveries, 1))
+	assert.Equal(t, deliveries[0].Event.Type, models.EventSessionCompleted)
+}
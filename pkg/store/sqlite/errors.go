@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/mattn/go-sqlite3"
+)
+
+// storeError gives the error of a transaction its type,
+// models.ErrNotFound and the typed errors are kept as is
+func storeError(err error) error {
+	var typed *models.Error
+	if err == nil || errors.Is(err, models.ErrNotFound) || errors.As(err, &typed) {
+		return err
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return &models.Error{Type: models.ErrorTypeDuplicate, Err: err}
+		case sqlite3.ErrConstraintForeignKey:
+			return &models.Error{Type: models.ErrorTypeForeignKey, Err: err}
+		case sqlite3.ErrConstraintNotNull:
+			return &models.Error{Type: models.ErrorTypeIncomplete, Err: err}
+		}
+		return &models.Error{Type: models.ErrorTypeInvalid, Err: err}
+	}
+	return &models.Error{Type: models.ErrorTypeQuery, Err: err}
+}
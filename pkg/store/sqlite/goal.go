@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+func (s SqliteStore) GoalSave(context context.Context, goal *models.Goal) (int, error) {
+	var goalID int
+	err := s.With(func(tx *sql.Tx) error {
+		now := time.Now()
+		goal.CreatedAt = &now
+		_, err := tx.Exec(
+			`INSERT INTO goal (period,unit,target,tag,created_at) VALUES ($1,$2,$3,$4,$5)`,
+			goal.Period,
+			goal.Unit,
+			goal.Target,
+			goal.Tag,
+			goal.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.QueryRow("SELECT last_insert_rowid() FROM goal").Scan(&goalID)
+	})
+	return goalID, err
+}
+
+func (s SqliteStore) GoalList(context context.Context) ([]models.Goal, error) {
+	goals := []models.Goal{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id,period,unit,target,tag,created_at FROM goal ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			goal := models.Goal{}
+			var createdAt sql.NullTime
+			if err := rows.Scan(&goal.ID, &goal.Period, &goal.Unit, &goal.Target, &goal.Tag, &createdAt); err != nil {
+				return err
+			}
+			if createdAt.Valid {
+				goal.CreatedAt = &createdAt.Time
+			}
+			goals = append(goals, goal)
+		}
+		return rows.Err()
+	})
+	return goals, err
+}
+
+func (s SqliteStore) GoalDeleteByID(context context.Context, goalID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec("DELETE FROM goal WHERE id = $1", &goalID)
+		return err
+	})
+}
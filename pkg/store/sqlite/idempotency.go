@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// idempotencyRetention is how long the responses are
+// recorded, a request replayed later is applied again
+const idempotencyRetention = 7 * 24 * time.Hour
+
+// IdempotentResponseGet returns the response recorded for
+// the key, models.ErrNotFound when there is none
+func (s SqliteStore) IdempotentResponseGet(context context.Context, key string) (*models.IdempotentResponse, error) {
+	response := &models.IdempotentResponse{Key: key}
+	var recorded int64
+	err := s.db.QueryRowContext(context, `SELECT time,status,body FROM idempotent_response WHERE key = $1`, key).
+		Scan(&recorded, &response.Status, &response.Body)
+	if err == sql.ErrNoRows {
+		return nil, models.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.Time = time.Unix(recorded, 0)
+	return response, nil
+}
+
+// IdempotentResponseSave records the response and
+// forgets the ones older than the retention
+func (s SqliteStore) IdempotentResponseSave(context context.Context, response *models.IdempotentResponse) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`INSERT OR REPLACE INTO idempotent_response (key, time, status, body) VALUES ($1, $2, $3, $4)`,
+			response.Key,
+			response.Time.Unix(),
+			response.Status,
+			response.Body,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM idempotent_response WHERE time < $1`, response.Time.Add(-idempotencyRetention).Unix())
+		return err
+	})
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Backup copies the database to a new file at path
+// with the online backup API, the store stays usable
+// while the copy is made
+func (s SqliteStore) Backup(context context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	dst, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	return copyDatabase(context, dst, s.db)
+}
+
+// Restore replaces the content of the database with
+// the backup at path once it passed the integrity check
+func (s SqliteStore) Restore(context context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	src, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	integrity, err := integrityCheck(context, src)
+	if err != nil {
+		return err
+	}
+	if len(integrity) > 0 {
+		return fmt.Errorf("%s is damaged: %s", path, integrity[0])
+	}
+	isPomo := false
+	err = src.QueryRowContext(context, `SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'task'`).Scan(&isPomo)
+	if err != nil {
+		return err
+	}
+	if !isPomo {
+		return fmt.Errorf("%s is not a pomo database", path)
+	}
+	if err := copyDatabase(context, s.db, src); err != nil {
+		return err
+	}
+	// backups of older versions lack the newer columns
+	return s.migrate()
+}
+
+// Vacuum rebuilds the database file to reclaim the unused space
+func (s SqliteStore) Vacuum(context context.Context) error {
+	_, err := s.db.ExecContext(context, "VACUUM")
+	return err
+}
+
+// Check runs the integrity check of the database
+// and looks for pomodoros left without a task
+func (s SqliteStore) Check(context context.Context) (*models.DatabaseCheck, error) {
+	check := &models.DatabaseCheck{OrphanedPomodoros: []*models.Pomodoro{}}
+	integrity, err := integrityCheck(context, s.db)
+	if err != nil {
+		return nil, err
+	}
+	check.Integrity = integrity
+	rows, err := s.db.QueryContext(context, `SELECT `+pomodoroColumns+` FROM pomodoro WHERE task_id NOT IN (SELECT id FROM task) ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		pomodoro := &models.Pomodoro{}
+		if err := scanPomodoro(rows, pomodoro); err != nil {
+			return nil, err
+		}
+		check.OrphanedPomodoros = append(check.OrphanedPomodoros, pomodoro)
+	}
+	return check, rows.Err()
+}
+
+// integrityCheck returns the problems found by
+// the integrity check, none when it passed
+func integrityCheck(context context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(context, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	problems := []string{}
+	for rows.Next() {
+		var problem string
+		if err := rows.Scan(&problem); err != nil {
+			return nil, err
+		}
+		if problem != "ok" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// copyDatabase copies every page of the src database over dst
+func copyDatabase(context context.Context, dst, src *sql.DB) error {
+	dstConn, err := dst.Conn(context)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	srcConn, err := src.Conn(context)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+	return dstConn.Raw(func(dstDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			to, ok := dstDriver.(*sqlite3.SQLiteConn)
+			from, ok2 := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok || !ok2 {
+				return errors.New("backups need a sqlite3 connection")
+			}
+			backup, err := to.Backup("main", from, "main")
+			if err != nil {
+				return err
+			}
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// NoteSave records a note about the task, a note about one
+// of its pomodoros is appended to the note of that pomodoro
+func (s SqliteStore) NoteSave(context context.Context, taskID int, note *models.Note) error {
+	if note.Pomodoro > 0 {
+		pomodoros, err := s.PomodoroGetByTaskID(context, taskID)
+		if err != nil {
+			return err
+		}
+		if note.Pomodoro > len(pomodoros) {
+			return models.ErrNotFound
+		}
+		return s.With(func(tx *sql.Tx) error {
+			_, err := tx.Exec(
+				`UPDATE pomodoro SET note = CASE WHEN note IS NULL OR note = '' THEN $1 ELSE note || char(10) || $1 END, updated_at = $2 WHERE id = $3`,
+				note.Text,
+				time.Now(),
+				pomodoros[note.Pomodoro-1].ID,
+			)
+			return err
+		})
+	}
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`INSERT INTO note (task_id, time, text) VALUES ($1, $2, $3)`,
+			taskID,
+			note.Time,
+			note.Text,
+		)
+		return err
+	})
+}
+
+func (s SqliteStore) NoteGetByTaskID(context context.Context, taskID int) ([]models.Note, error) {
+	var notes []models.Note
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT time,text FROM note WHERE task_id = $1 ORDER BY id`, &taskID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			note := models.Note{}
+			if err := rows.Scan(&note.Time, &note.Text); err != nil {
+				return err
+			}
+			notes = append(notes, note)
+		}
+		return rows.Err()
+	})
+	return notes, err
+}
@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+func (s SqliteStore) ProjectSave(context context.Context, project *models.Project) (int, error) {
+	var projectID int
+	err := s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`INSERT INTO project (name,color,parent_id,archived) VALUES ($1,$2,$3,$4)`,
+			project.Name,
+			project.Color,
+			project.ParentID,
+			project.Archived,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.QueryRow("SELECT last_insert_rowid() FROM project").Scan(&projectID)
+	})
+	return projectID, err
+}
+
+func (s SqliteStore) ProjectList(context context.Context) (models.Projects, error) {
+	projects := models.Projects{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id,name,color,parent_id,archived FROM project ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			project := models.Project{}
+			if err := rows.Scan(&project.ID, &project.Name, &project.Color, &project.ParentID, &project.Archived); err != nil {
+				return err
+			}
+			projects = append(projects, project)
+		}
+		return rows.Err()
+	})
+	return projects, err
+}
+
+func (s SqliteStore) ProjectArchiveByID(context context.Context, projectID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec("UPDATE project SET archived = 1 WHERE id = $1", &projectID)
+		return err
+	})
+}
@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -34,72 +36,77 @@ func NewStore(path string) (core.Store, error) {
 func (s SqliteStore) With(fns ...func(tx *sql.Tx) error) error {
 	tx, err := s.db.Begin()
 	if err != nil {
-		return err
+		return storeError(err)
 	}
 	for _, fn := range fns {
 		err = fn(tx)
 		if err != nil {
 			tx.Rollback()
-			return err
+			return storeError(err)
 		}
 	}
-	return tx.Commit()
+	return storeError(tx.Commit())
 }
 
 func (s SqliteStore) TaskSave(context context.Context, task *models.Task) (int, error) {
 	var taskID int
-
 	err := s.With(func(tx *sql.Tx) error {
-		_, err := tx.Exec(
-			"INSERT INTO task (message,pomodoros,duration,tags) VALUES ($1,$2,$3,$4)",
-			task.Message,
-			task.NPomodoros,
-			task.Duration.String(),
-			strings.Join(task.Tags, ","))
-		if err != nil {
-			return err
-		}
-		err = tx.QueryRow("SELECT last_insert_rowid() FROM task").Scan(&taskID)
-		if err != nil {
-			return err
-		}
-		err = tx.QueryRow("SELECT last_insert_rowid() FROM task").Scan(&taskID)
-		if err != nil {
-			return err
-		}
-		return nil
+		var err error
+		taskID, err = insertTask(tx, task)
+		return err
 	})
 	return taskID, err
 }
 
+// insertTask saves a new task and returns its ID
+func insertTask(tx *sql.Tx, task *models.Task) (int, error) {
+	var taskID int
+	if task.UUID == "" {
+		task.UUID = models.NewUUID()
+	}
+	now := time.Now()
+	task.UpdatedAt = &now
+	_, err := tx.Exec(
+		"INSERT INTO task (message,pomodoros,duration,tags,break_duration,auto_start_break,auto_start_pomodoro,project_id,uuid,updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)",
+		task.Message,
+		task.NPomodoros,
+		task.Duration.String(),
+		strings.Join(task.Tags, ","),
+		task.BreakDuration.String(),
+		nullBool(task.AutoStartBreak),
+		nullBool(task.AutoStartPomodoro),
+		task.ProjectID,
+		task.UUID,
+		task.UpdatedAt)
+	if err != nil {
+		return -1, err
+	}
+	err = tx.QueryRow("SELECT last_insert_rowid() FROM task").Scan(&taskID)
+	return taskID, err
+}
+
 func (s SqliteStore) GetAllTasks(context context.Context) (models.List, error) {
 	tasks := []models.Task{}
 
 	err := s.With(func(tx *sql.Tx) error {
-		rows, err := tx.Query(`SELECT rowid,message,pomodoros,duration,tags FROM task`)
+		rows, err := tx.Query(`SELECT ` + taskColumns + ` FROM task WHERE deleted_at IS NULL`)
 		if err != nil {
 			return err
 		}
 		for rows.Next() {
-			var (
-				tags        string
-				strDuration string
-			)
 			task := &models.Task{Pomodoros: []*models.Pomodoro{}}
-			err = rows.Scan(&task.ID, &task.Message, &task.NPomodoros, &strDuration, &tags)
+			err = scanTask(rows, task)
 			if err != nil {
 				return err
 			}
-			duration, _ := time.ParseDuration(strDuration)
-			task.Duration = duration
-			if tags != "" {
-				task.Tags = strings.Split(tags, ",")
-			}
 			pomodoros, err := s.PomodoroGetByTaskID(context, task.ID)
 			if err != nil {
 				return err
 			}
 			task.Pomodoros = append(task.Pomodoros, pomodoros...)
+			if task.Notes, err = s.NoteGetByTaskID(context, task.ID); err != nil {
+				return err
+			}
 			tasks = append(tasks, *task)
 		}
 		return nil
@@ -107,110 +114,490 @@ func (s SqliteStore) GetAllTasks(context context.Context) (models.List, error) {
 	return tasks, err
 }
 
+// TaskDeleteByID moves the task to the trash, it is kept with its
+// pomodoros until purged, models.ErrNotFound when missing or trashed
 func (s SqliteStore) TaskDeleteByID(context context.Context, taskID int) error {
-
-	err := s.With(func(tx *sql.Tx) error {
-		_, err := tx.Exec("DELETE FROM task WHERE rowid = $1", &taskID)
-		if err != nil {
-			return err
-		}
-		_, err = tx.Exec("DELETE FROM pomodoro WHERE task_id = $1", &taskID)
+	return s.With(func(tx *sql.Tx) error {
+		result, err := tx.Exec("UPDATE task SET deleted_at = $1, updated_at = $1 WHERE id = $2 AND deleted_at IS NULL", time.Now(), &taskID)
 		if err != nil {
 			return err
 		}
-		return nil
+		return notFound(result)
 	})
-	return err
 }
 
+// TaskGetByID returns the task, models.ErrNotFound when missing or trashed
 func (s SqliteStore) TaskGetByID(context context.Context, taskID int) (*models.Task, error) {
 	task := &models.Task{}
 
 	err := s.With(func(tx *sql.Tx) error {
-		var (
-			tags        string
-			strDuration string
-		)
-		err := tx.QueryRow(`SELECT rowid,message,pomodoros,duration,tags FROM task WHERE rowid = $1`, &taskID).
-			Scan(&task.ID, &task.Message, &task.NPomodoros, &strDuration, &tags)
-		if err != nil {
-			return nil
-		}
-		duration, _ := time.ParseDuration(strDuration)
-		task.Duration = duration
-		if tags != "" {
-			task.Tags = strings.Split(tags, ",")
+		err := scanTask(tx.QueryRow(`SELECT `+taskColumns+` FROM task WHERE id = $1 AND deleted_at IS NULL`, &taskID), task)
+		if err == sql.ErrNoRows {
+			return models.ErrNotFound
 		}
-		return nil
+		return err
 	})
-	return task, err
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// taskExists returns models.ErrNotFound when there
+// is no task with the ID, in the trash or not
+func taskExists(tx *sql.Tx, taskID int) error {
+	var exists bool
+	if err := tx.QueryRow(`SELECT COUNT(*) > 0 FROM task WHERE id = $1`, taskID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return models.ErrNotFound
+	}
+	return nil
 }
 
+// taskActive returns models.ErrNotFound when there
+// is no task with the ID or when it is in the trash
+func taskActive(tx *sql.Tx, taskID int) error {
+	var exists bool
+	if err := tx.QueryRow(`SELECT COUNT(*) > 0 FROM task WHERE id = $1 AND deleted_at IS NULL`, taskID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// taskColumns are the columns read by scanTask
+const taskColumns = "id,message,pomodoros,duration,tags,break_duration,auto_start_break,auto_start_pomodoro,project_id,deleted_at,uuid,updated_at"
+
+// scanner is implemented by sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads the taskColumns of a row into task
+func scanTask(row scanner, task *models.Task) error {
+	var (
+		tags              string
+		strDuration       string
+		strBreakDuration  string
+		autoStartBreak    sql.NullBool
+		autoStartPomodoro sql.NullBool
+		deletedAt         sql.NullTime
+		updatedAt         sql.NullTime
+	)
+	err := row.Scan(&task.ID, &task.Message, &task.NPomodoros, &strDuration, &tags,
+		&strBreakDuration, &autoStartBreak, &autoStartPomodoro, &task.ProjectID, &deletedAt,
+		&task.UUID, &updatedAt)
+	if err != nil {
+		return err
+	}
+	task.Duration, _ = time.ParseDuration(strDuration)
+	task.BreakDuration, _ = time.ParseDuration(strBreakDuration)
+	if tags != "" {
+		task.Tags = strings.Split(tags, ",")
+	}
+	if autoStartBreak.Valid {
+		task.AutoStartBreak = &autoStartBreak.Bool
+	}
+	if autoStartPomodoro.Valid {
+		task.AutoStartPomodoro = &autoStartPomodoro.Bool
+	}
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if updatedAt.Valid {
+		task.UpdatedAt = &updatedAt.Time
+	}
+	return nil
+}
+
+// nullBool stores an optional boolean as NULL when it is not set
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// PomodoroSave appends the pomodoro to a task which is not in the
+// trash, it returns models.ErrNotFound when there is no such task
 func (s SqliteStore) PomodoroSave(context context.Context, taskID int, pomodoro *models.Pomodoro) error {
 	err := s.With(func(tx *sql.Tx) error {
+		if err := taskActive(tx, taskID); err != nil {
+			return err
+		}
+		if pomodoro.UUID == "" {
+			pomodoro.UUID = models.NewUUID()
+		}
+		now := time.Now()
+		pomodoro.UpdatedAt = &now
 		_, err := tx.Exec(
-			`INSERT INTO pomodoro (task_id, start, end) VALUES ($1, $2, $3)`,
+			`INSERT INTO pomodoro (task_id, start, end, note, uuid, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
 			taskID,
 			pomodoro.Start,
 			pomodoro.End,
+			pomodoro.Note,
+			pomodoro.UUID,
+			pomodoro.UpdatedAt,
 		)
-		return err
+		if err != nil {
+			return err
+		}
+		pomodoro.TaskID = taskID
+		return tx.QueryRow("SELECT last_insert_rowid() FROM pomodoro").Scan(&pomodoro.ID)
 	})
 	return err
 }
 
+// pomodoroColumns are the columns read by scanPomodoro
+const pomodoroColumns = "id,task_id,start,end,note,uuid,updated_at"
+
+// scanPomodoro reads the pomodoroColumns of a row into pomodoro
+func scanPomodoro(row scanner, pomodoro *models.Pomodoro) error {
+	var (
+		startStr  string
+		endStr    string
+		updatedAt sql.NullTime
+	)
+	err := row.Scan(&pomodoro.ID, &pomodoro.TaskID, &startStr, &endStr, &pomodoro.Note, &pomodoro.UUID, &updatedAt)
+	if err != nil {
+		return err
+	}
+	pomodoro.Start, _ = time.Parse(datetimeFmt, startStr)
+	pomodoro.End, _ = time.Parse(datetimeFmt, endStr)
+	if updatedAt.Valid {
+		pomodoro.UpdatedAt = &updatedAt.Time
+	}
+	return nil
+}
+
+// PomodoroGetByTaskID returns the pomodoros of the task in the order they
+// were started, it returns models.ErrNotFound when there is no such task
 func (s SqliteStore) PomodoroGetByTaskID(context context.Context, taskID int) ([]*models.Pomodoro, error) {
 	pomodoros := []*models.Pomodoro{}
 	err := s.With(func(tx *sql.Tx) error {
-		rows, err := tx.Query(`SELECT start,end FROM pomodoro WHERE task_id = $1`, &taskID)
+		if err := taskExists(tx, taskID); err != nil {
+			return err
+		}
+		rows, err := tx.Query(`SELECT `+pomodoroColumns+` FROM pomodoro WHERE task_id = $1 ORDER BY id`, &taskID)
 		if err != nil {
-			return nil
+			return err
 		}
+		defer rows.Close()
 		for rows.Next() {
-			var (
-				startStr string
-				endStr   string
-			)
 			pomodoro := &models.Pomodoro{}
-			err = rows.Scan(&startStr, &endStr)
+			err = scanPomodoro(rows, pomodoro)
 			if err != nil {
 				return err
 			}
-			start, _ := time.Parse(datetimeFmt, startStr)
-			end, _ := time.Parse(datetimeFmt, endStr)
-			pomodoro.Start = start
-			pomodoro.End = end
 			pomodoros = append(pomodoros, pomodoro)
 		}
-		return nil
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortPomodoros(pomodoros)
+	return pomodoros, nil
+}
+
+// sortPomodoros orders the pomodoros of a task by their start,
+// the ones entered by hand are stored after the ones they precede
+func sortPomodoros(pomodoros []*models.Pomodoro) {
+	sort.SliceStable(pomodoros, func(i, j int) bool {
+		return pomodoros[i].Start.Before(pomodoros[j].Start)
+	})
+}
+
+// activeTask matches the pomodoros of the tasks out of the trash
+const activeTask = `task_id IN (SELECT id FROM task WHERE deleted_at IS NULL)`
+
+// PomodoroList returns the pomodoros matching
+// the query in the order they were started
+func (s SqliteStore) PomodoroList(context context.Context, query models.PomodoroQuery) ([]*models.Pomodoro, error) {
+	pomodoros := []*models.Pomodoro{}
+	err := s.With(func(tx *sql.Tx) error {
+		// the range is matched once the times are parsed
+		rows, err := tx.Query(`SELECT `+pomodoroColumns+` FROM pomodoro WHERE ($1 = 0 OR task_id = $1)
+			AND `+activeTask, query.TaskID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			pomodoro := &models.Pomodoro{}
+			if err := scanPomodoro(rows, pomodoro); err != nil {
+				return err
+			}
+			if query.Match(*pomodoro) {
+				pomodoros = append(pomodoros, pomodoro)
+			}
+		}
+		return rows.Err()
+	})
+	sort.SliceStable(pomodoros, func(i, j int) bool {
+		return pomodoros[i].Start.Before(pomodoros[j].Start)
 	})
 	return pomodoros, err
 }
 
+// PomodoroGetByID returns the pomodoro, models.ErrNotFound
+// when it is missing or its task is in the trash
+func (s SqliteStore) PomodoroGetByID(context context.Context, pomodoroID int) (*models.Pomodoro, error) {
+	pomodoro := &models.Pomodoro{}
+	err := s.With(func(tx *sql.Tx) error {
+		err := scanPomodoro(tx.QueryRow(`SELECT `+pomodoroColumns+` FROM pomodoro WHERE id = $1 AND `+activeTask, &pomodoroID), pomodoro)
+		if err == sql.ErrNoRows {
+			return models.ErrNotFound
+		}
+		return err
+	})
+	return pomodoro, err
+}
+
+// PomodoroUpdate saves the times and the note of the pomodoro,
+// models.ErrNotFound when it is missing or its task is in the trash
+func (s SqliteStore) PomodoroUpdate(context context.Context, pomodoro *models.Pomodoro) error {
+	return s.With(func(tx *sql.Tx) error {
+		result, err := tx.Exec(
+			`UPDATE pomodoro SET start = $1, end = $2, note = $3, updated_at = $4 WHERE id = $5 AND `+activeTask,
+			pomodoro.Start,
+			pomodoro.End,
+			pomodoro.Note,
+			time.Now(),
+			pomodoro.ID,
+		)
+		if err != nil {
+			return err
+		}
+		return notFound(result)
+	})
+}
+
+// PomodoroDeleteByTaskID deletes the pomodoros of
+// the task, models.ErrNotFound when it is missing
 func (s SqliteStore) PomodoroDeleteByTaskID(context context.Context, taskID int) error {
 	err := s.With(func(tx *sql.Tx) error {
+		if err := taskExists(tx, taskID); err != nil {
+			return err
+		}
+		if err := bury(tx, "pomodoro", "task_id = $2", taskID); err != nil {
+			return err
+		}
 		_, err := tx.Exec("DELETE FROM pomodoro WHERE task_id = $1", &taskID)
 		return err
 	})
 	return err
 }
 
+// PomodoroDeleteByID deletes a single pomodoro, models.ErrNotFound when missing
+func (s SqliteStore) PomodoroDeleteByID(context context.Context, pomodoroID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		if err := bury(tx, "pomodoro", "id = $2", pomodoroID); err != nil {
+			return err
+		}
+		result, err := tx.Exec("DELETE FROM pomodoro WHERE id = $1", &pomodoroID)
+		if err != nil {
+			return err
+		}
+		return notFound(result)
+	})
+}
+
+// notFound returns models.ErrNotFound when no row was affected
+func notFound(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
 func (s SqliteStore) Close() error { return s.db.Close() }
 
 func (s SqliteStore) InitDB() error {
 	stmt := `
-    CREATE TABLE task (
+    CREATE TABLE IF NOT EXISTS task (
+	id INTEGER PRIMARY KEY,
 	message TEXT,
 	pomodoros INTEGER,
 	duration TEXT,
 	tags TEXT
     );
-    CREATE TABLE pomodoro (
+    CREATE TABLE IF NOT EXISTS pomodoro (
+	id INTEGER PRIMARY KEY,
 	task_id INTEGER,
 	start DATETTIME,
 	end DATETTIME
     );
+    CREATE TABLE IF NOT EXISTS webhook_delivery (
+	id INTEGER PRIMARY KEY,
+	endpoint TEXT,
+	event TEXT,
+	attempts INTEGER,
+	next_attempt DATETIME,
+	last_error TEXT,
+	failed BOOLEAN
+    );
+    CREATE TABLE IF NOT EXISTS goal (
+	id INTEGER PRIMARY KEY,
+	period TEXT,
+	unit TEXT,
+	target REAL,
+	tag TEXT
+    );
+    CREATE TABLE IF NOT EXISTS project (
+	id INTEGER PRIMARY KEY,
+	name TEXT,
+	color TEXT,
+	parent_id INTEGER,
+	archived BOOLEAN
+    );
+    CREATE TABLE IF NOT EXISTS tag (
+	name TEXT PRIMARY KEY,
+	color TEXT
+    );
+    CREATE TABLE IF NOT EXISTS note (
+	id INTEGER PRIMARY KEY,
+	task_id INTEGER,
+	time DATETIME,
+	text TEXT
+    );
+    CREATE TABLE IF NOT EXISTS template (
+	id INTEGER PRIMARY KEY,
+	name TEXT,
+	message TEXT,
+	pomodoros INTEGER,
+	duration TEXT,
+	tags TEXT,
+	project_id INTEGER,
+	recurrence TEXT,
+	weekday INTEGER,
+	last_created DATETIME
+    );
+    CREATE TABLE IF NOT EXISTS tombstone (
+	uuid TEXT PRIMARY KEY,
+	deleted_at DATETIME
+    );
+    CREATE TABLE IF NOT EXISTS idempotent_response (
+	key TEXT PRIMARY KEY,
+	time INTEGER,
+	status INTEGER,
+	body BLOB
+    );
     `
 	_, err := s.db.Exec(stmt)
-	return err
+	if err != nil {
+		return err
+	}
+	return s.migrate()
+}
+
+// columns were added to the tables after their creation,
+// migrate adds the ones missing from older databases
+var columns = []struct {
+	table      string
+	name       string
+	definition string
+}{
+	{"task", "break_duration", "TEXT DEFAULT ''"},
+	{"task", "auto_start_break", "BOOLEAN"},
+	{"task", "auto_start_pomodoro", "BOOLEAN"},
+	{"task", "project_id", "INTEGER DEFAULT 0"},
+	{"pomodoro", "note", "TEXT DEFAULT ''"},
+	{"task", "deleted_at", "DATETIME"},
+	{"task", "uuid", "TEXT DEFAULT ''"},
+	{"task", "updated_at", "DATETIME"},
+	{"pomodoro", "uuid", "TEXT DEFAULT ''"},
+	{"pomodoro", "updated_at", "DATETIME"},
+	{"goal", "created_at", "DATETIME"},
+}
+
+// migrate adds the missing columns
+func (s SqliteStore) migrate() error {
+	for _, column := range columns {
+		exists := false
+		err := s.db.QueryRow(
+			`SELECT COUNT(*) > 0 FROM pragma_table_info($1) WHERE name = $2`,
+			column.table, column.name).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.name, column.definition))
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.addIDs(); err != nil {
+		return err
+	}
+	return s.addUUIDs()
+}
+
+// keyedTables identify their rows by an id column, VACUUM
+// may renumber the rowids of the tables without one
+var keyedTables = []string{"task", "pomodoro", "webhook_delivery", "goal", "project", "note", "template"}
+
+// addIDs rebuilds the tables created without an id
+// column, the rowids of their rows become the IDs
+func (s SqliteStore) addIDs() error {
+	for _, table := range keyedTables {
+		rows, err := s.db.Query(`SELECT name, type, dflt_value FROM pragma_table_info($1)`, table)
+		if err != nil {
+			return err
+		}
+		names, definitions := []string{}, []string{}
+		keyed := false
+		for rows.Next() {
+			var (
+				name, kind string
+				dflt       sql.NullString
+			)
+			if err := rows.Scan(&name, &kind, &dflt); err != nil {
+				rows.Close()
+				return err
+			}
+			keyed = keyed || name == "id"
+			definition := fmt.Sprintf(`"%s" %s`, name, kind)
+			if dflt.Valid {
+				definition += " DEFAULT " + dflt.String
+			}
+			names = append(names, fmt.Sprintf(`"%s"`, name))
+			definitions = append(definitions, definition)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if keyed {
+			continue
+		}
+		err = s.With(func(tx *sql.Tx) error {
+			for _, stmt := range []string{
+				fmt.Sprintf(`CREATE TABLE %s_keyed (id INTEGER PRIMARY KEY, %s)`, table, strings.Join(definitions, ", ")),
+				fmt.Sprintf(`INSERT INTO %[1]s_keyed (id, %[2]s) SELECT rowid, %[2]s FROM %[1]s`, table, strings.Join(names, ", ")),
+				fmt.Sprintf(`DROP TABLE %s`, table),
+				fmt.Sprintf(`ALTER TABLE %s_keyed RENAME TO %[1]s`, table),
+			} {
+				if _, err := tx.Exec(stmt); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
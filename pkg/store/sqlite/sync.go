@@ -0,0 +1,358 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// SyncExport returns every task, the trashed ones too, with
+// its pomodoros and notes and the tombstones of the deleted ones
+func (s SqliteStore) SyncExport(context context.Context) (*models.SyncBatch, error) {
+	batch := &models.SyncBatch{Tasks: []models.Task{}, Tombstones: []models.Tombstone{}, Notes: []models.SyncNote{}}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT ` + taskColumns + ` FROM task ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				return err
+			}
+			batch.Tasks = append(batch.Tasks, task)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		// read in the same transaction so that the batch is consistent
+		pomodoros := map[int][]*models.Pomodoro{}
+		rows, err = tx.Query(`SELECT ` + pomodoroColumns + ` FROM pomodoro ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			pomodoro := &models.Pomodoro{}
+			if err := scanPomodoro(rows, pomodoro); err != nil {
+				return err
+			}
+			pomodoros[pomodoro.TaskID] = append(pomodoros[pomodoro.TaskID], pomodoro)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for i := range batch.Tasks {
+			batch.Tasks[i].Pomodoros = pomodoros[batch.Tasks[i].ID]
+			if batch.Tasks[i].Pomodoros == nil {
+				batch.Tasks[i].Pomodoros = []*models.Pomodoro{}
+			}
+			sortPomodoros(batch.Tasks[i].Pomodoros)
+		}
+		rows, err = tx.Query(`SELECT uuid, deleted_at FROM tombstone ORDER BY deleted_at`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			tombstone := models.Tombstone{}
+			if err := rows.Scan(&tombstone.UUID, &tombstone.DeletedAt); err != nil {
+				return err
+			}
+			batch.Tombstones = append(batch.Tombstones, tombstone)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows, err = tx.Query(`SELECT task.uuid, note.time, note.text FROM note JOIN task ON task.id = note.task_id ORDER BY note.id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			note := models.SyncNote{}
+			if err := rows.Scan(&note.Task, &note.Time, &note.Text); err != nil {
+				return err
+			}
+			batch.Notes = append(batch.Notes, note)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// SyncMerge applies the batch of another server: the deletions
+// remove the rows not changed since, the unknown tasks and
+// pomodoros are created and the known ones take the newest
+// change, the notes missing are added to their task and
+// the projects of the tasks are not shared
+func (s SqliteStore) SyncMerge(context context.Context, batch *models.SyncBatch) (*models.SyncResult, error) {
+	result := &models.SyncResult{}
+	err := s.With(func(tx *sql.Tx) error {
+		for _, tombstone := range batch.Tombstones {
+			if err := mergeTombstone(tx, tombstone, result); err != nil {
+				return err
+			}
+		}
+		for _, task := range batch.Tasks {
+			if err := mergeTask(tx, task, result); err != nil {
+				return err
+			}
+		}
+		for _, note := range batch.Notes {
+			if err := mergeNote(tx, note, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func mergeTombstone(tx *sql.Tx, tombstone models.Tombstone, result *models.SyncResult) error {
+	_, err := tx.Exec(`INSERT OR IGNORE INTO tombstone (uuid, deleted_at) VALUES ($1, $2)`, tombstone.UUID, tombstone.DeletedAt)
+	if err != nil {
+		return err
+	}
+	task := &models.Task{}
+	err = scanTask(tx.QueryRow(`SELECT `+taskColumns+` FROM task WHERE uuid = $1`, tombstone.UUID), task)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	// a change made after the deletion keeps the task
+	if err == nil && !models.Newer(task.UpdatedAt, &tombstone.DeletedAt) {
+		if err := bury(tx, "pomodoro", "task_id = $2", task.ID); err != nil {
+			return err
+		}
+		for _, stmt := range purgeStatements {
+			if _, err := tx.Exec(stmt, task.ID); err != nil {
+				return err
+			}
+		}
+		result.Deleted++
+		return nil
+	}
+	pomodoro := &models.Pomodoro{}
+	err = scanPomodoro(tx.QueryRow(`SELECT `+pomodoroColumns+` FROM pomodoro WHERE uuid = $1`, tombstone.UUID), pomodoro)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !models.Newer(pomodoro.UpdatedAt, &tombstone.DeletedAt) {
+		if _, err := tx.Exec(`DELETE FROM pomodoro WHERE id = $1`, pomodoro.ID); err != nil {
+			return err
+		}
+		result.Deleted++
+	}
+	return nil
+}
+
+func mergeTask(tx *sql.Tx, incoming models.Task, result *models.SyncResult) error {
+	if incoming.UUID == "" {
+		return nil
+	}
+	if buried, err := buriedSince(tx, incoming.UUID, incoming.UpdatedAt); err != nil || buried {
+		return err
+	}
+	local := &models.Task{}
+	err := scanTask(tx.QueryRow(`SELECT `+taskColumns+` FROM task WHERE uuid = $1`, incoming.UUID), local)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			"INSERT INTO task (message,pomodoros,duration,tags,break_duration,auto_start_break,auto_start_pomodoro,project_id,deleted_at,uuid,updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,0,$8,$9,$10)",
+			incoming.Message,
+			incoming.NPomodoros,
+			incoming.Duration.String(),
+			strings.Join(incoming.Tags, ","),
+			incoming.BreakDuration.String(),
+			nullBool(incoming.AutoStartBreak),
+			nullBool(incoming.AutoStartPomodoro),
+			incoming.DeletedAt,
+			incoming.UUID,
+			incoming.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if err := tx.QueryRow("SELECT last_insert_rowid() FROM task").Scan(&local.ID); err != nil {
+			return err
+		}
+		result.Created++
+	case err != nil:
+		return err
+	case models.Newer(incoming.UpdatedAt, local.UpdatedAt):
+		_, err = tx.Exec(
+			"UPDATE task SET message = $1, pomodoros = $2, duration = $3, tags = $4, break_duration = $5, auto_start_break = $6, auto_start_pomodoro = $7, deleted_at = $8, updated_at = $9 WHERE id = $10",
+			incoming.Message,
+			incoming.NPomodoros,
+			incoming.Duration.String(),
+			strings.Join(incoming.Tags, ","),
+			incoming.BreakDuration.String(),
+			nullBool(incoming.AutoStartBreak),
+			nullBool(incoming.AutoStartPomodoro),
+			incoming.DeletedAt,
+			incoming.UpdatedAt,
+			local.ID)
+		if err != nil {
+			return err
+		}
+		result.Updated++
+	}
+	// the pomodoros are merged whichever change of the task is kept
+	for _, pomodoro := range incoming.Pomodoros {
+		if err := mergePomodoro(tx, local.ID, *pomodoro, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergePomodoro(tx *sql.Tx, taskID int, incoming models.Pomodoro, result *models.SyncResult) error {
+	if incoming.UUID == "" {
+		return nil
+	}
+	if buried, err := buriedSince(tx, incoming.UUID, incoming.UpdatedAt); err != nil || buried {
+		return err
+	}
+	local := &models.Pomodoro{}
+	err := scanPomodoro(tx.QueryRow(`SELECT `+pomodoroColumns+` FROM pomodoro WHERE uuid = $1`, incoming.UUID), local)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			`INSERT INTO pomodoro (task_id, start, end, note, uuid, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			taskID,
+			incoming.Start,
+			incoming.End,
+			incoming.Note,
+			incoming.UUID,
+			incoming.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		result.Created++
+	case err != nil:
+		return err
+	case models.Newer(incoming.UpdatedAt, local.UpdatedAt):
+		_, err = tx.Exec(
+			`UPDATE pomodoro SET start = $1, end = $2, note = $3, updated_at = $4 WHERE id = $5`,
+			incoming.Start,
+			incoming.End,
+			incoming.Note,
+			incoming.UpdatedAt,
+			local.ID,
+		)
+		if err != nil {
+			return err
+		}
+		result.Updated++
+	}
+	return nil
+}
+
+func mergeNote(tx *sql.Tx, incoming models.SyncNote, result *models.SyncResult) error {
+	if incoming.Task == "" {
+		return nil
+	}
+	var taskID int
+	err := tx.QueryRow(`SELECT id FROM task WHERE uuid = $1`, incoming.Task).Scan(&taskID)
+	// the task was deleted for good
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// the times are compared in go as sqlite keeps the zone they were written in
+	rows, err := tx.Query(`SELECT time FROM note WHERE task_id = $1 AND text = $2`, taskID, incoming.Text)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var noted time.Time
+		if err := rows.Scan(&noted); err != nil {
+			return err
+		}
+		if noted.Equal(incoming.Time) {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+	_, err = tx.Exec(
+		`INSERT INTO note (task_id, time, text) VALUES ($1, $2, $3)`,
+		taskID,
+		incoming.Time,
+		incoming.Text,
+	)
+	if err != nil {
+		return err
+	}
+	result.Created++
+	return nil
+}
+
+// buriedSince reports whether the task or pomodoro
+// was deleted after its change made at updated
+func buriedSince(tx *sql.Tx, uuid string, updated *time.Time) (bool, error) {
+	var deletedAt time.Time
+	err := tx.QueryRow(`SELECT deleted_at FROM tombstone WHERE uuid = $1`, uuid).Scan(&deletedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !models.Newer(updated, &deletedAt), nil
+}
+
+// bury records the tombstones of the rows of the table
+// matching the condition on $2 before they are deleted
+func bury(tx *sql.Tx, table, condition string, arg interface{}) error {
+	_, err := tx.Exec(
+		fmt.Sprintf(`INSERT OR REPLACE INTO tombstone (uuid, deleted_at) SELECT uuid, $1 FROM %s WHERE %s AND uuid != ''`, table, condition),
+		time.Now(), arg)
+	return err
+}
+
+// addUUIDs identifies the tasks and pomodoros
+// saved before they were given a UUID
+func (s SqliteStore) addUUIDs() error {
+	for _, table := range []string{"task", "pomodoro"} {
+		rows, err := s.db.Query(fmt.Sprintf(`SELECT id FROM %s WHERE uuid IS NULL OR uuid = ''`, table))
+		if err != nil {
+			return err
+		}
+		ids := []int{}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		for _, id := range ids {
+			if _, err := s.db.Exec(fmt.Sprintf(`UPDATE %s SET uuid = $1 WHERE id = $2`, table), models.NewUUID(), id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TagList returns the tags of the tasks out of the trash and the tags
+// given a color, with the number of tasks of each
+func (s SqliteStore) TagList(context context.Context) (models.Tags, error) {
+	byName := map[string]*models.Tag{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT tags FROM task WHERE tags != '' AND deleted_at IS NULL`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var tags string
+			if err := rows.Scan(&tags); err != nil {
+				return err
+			}
+			for _, name := range strings.Split(tags, ",") {
+				if byName[name] == nil {
+					byName[name] = &models.Tag{Name: name}
+				}
+				byName[name].Count++
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		colors, err := tx.Query(`SELECT name,color FROM tag`)
+		if err != nil {
+			return err
+		}
+		defer colors.Close()
+		for colors.Next() {
+			tag := models.Tag{}
+			if err := colors.Scan(&tag.Name, &tag.Color); err != nil {
+				return err
+			}
+			if byName[tag.Name] == nil {
+				byName[tag.Name] = &models.Tag{Name: tag.Name}
+			}
+			byName[tag.Name].Color = tag.Color
+		}
+		return colors.Err()
+	})
+	tags := models.Tags{}
+	for _, tag := range byName {
+		tags = append(tags, *tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, err
+}
+
+// TagSave sets the color of a tag, an empty color removes it
+func (s SqliteStore) TagSave(context context.Context, tag *models.Tag) error {
+	return s.With(func(tx *sql.Tx) error {
+		if tag.Color == "" {
+			_, err := tx.Exec(`DELETE FROM tag WHERE name = $1`, tag.Name)
+			return err
+		}
+		_, err := tx.Exec(`INSERT OR REPLACE INTO tag (name,color) VALUES ($1,$2)`, tag.Name, tag.Color)
+		return err
+	})
+}
+
+// TagRename replaces the renamed tags in the tasks out of the trash, To
+// keeps its color or takes the one of the first renamed tag
+func (s SqliteStore) TagRename(context context.Context, rename models.TagRename) error {
+	return s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id,tags FROM task WHERE tags != '' AND deleted_at IS NULL`)
+		if err != nil {
+			return err
+		}
+		renamed := map[int]string{}
+		for rows.Next() {
+			var (
+				taskID int
+				tags   string
+			)
+			if err := rows.Scan(&taskID, &tags); err != nil {
+				rows.Close()
+				return err
+			}
+			if after := strings.Join(rename.Apply(strings.Split(tags, ",")), ","); after != tags {
+				renamed[taskID] = after
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for taskID, tags := range renamed {
+			if _, err := tx.Exec(`UPDATE task SET tags = $1, updated_at = $2 WHERE id = $3`, tags, time.Now(), taskID); err != nil {
+				return err
+			}
+		}
+
+		var color string
+		err = tx.QueryRow(`SELECT color FROM tag WHERE name = $1`, rename.To).Scan(&color)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		for _, from := range rename.From {
+			if from == rename.To {
+				continue
+			}
+			if color == "" {
+				err = tx.QueryRow(`SELECT color FROM tag WHERE name = $1`, from).Scan(&color)
+				if err != nil && err != sql.ErrNoRows {
+					return err
+				}
+			}
+			if _, err := tx.Exec(`DELETE FROM tag WHERE name = $1`, from); err != nil {
+				return err
+			}
+		}
+		if color == "" {
+			return nil
+		}
+		_, err = tx.Exec(`INSERT OR REPLACE INTO tag (name,color) VALUES ($1,$2)`, rename.To, color)
+		return err
+	})
+}
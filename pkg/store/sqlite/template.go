@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+func (s SqliteStore) TemplateSave(context context.Context, template *models.Template) (int, error) {
+	var templateID int
+	err := s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`INSERT INTO template (name,message,pomodoros,duration,tags,project_id,recurrence,weekday,last_created) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`,
+			template.Name,
+			template.Message,
+			template.NPomodoros,
+			template.Duration.String(),
+			strings.Join(template.Tags, ","),
+			template.ProjectID,
+			template.Recurrence,
+			template.Weekday,
+			template.LastCreated,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.QueryRow("SELECT last_insert_rowid() FROM template").Scan(&templateID)
+	})
+	return templateID, err
+}
+
+func (s SqliteStore) TemplateList(context context.Context) (models.Templates, error) {
+	templates := models.Templates{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id,name,message,pomodoros,duration,tags,project_id,recurrence,weekday,last_created FROM template ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				duration string
+				tags     string
+			)
+			template := models.Template{}
+			err := rows.Scan(&template.ID, &template.Name, &template.Message, &template.NPomodoros, &duration, &tags,
+				&template.ProjectID, &template.Recurrence, &template.Weekday, &template.LastCreated)
+			if err != nil {
+				return err
+			}
+			if template.Duration, err = time.ParseDuration(duration); err != nil {
+				return err
+			}
+			if tags != "" {
+				template.Tags = strings.Split(tags, ",")
+			}
+			templates = append(templates, template)
+		}
+		return rows.Err()
+	})
+	return templates, err
+}
+
+func (s SqliteStore) TemplateDeleteByID(context context.Context, templateID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec("DELETE FROM template WHERE id = $1", &templateID)
+		return err
+	})
+}
+
+// TemplateMaterialise saves the task of the template and
+// records it was created at created in one transaction
+func (s SqliteStore) TemplateMaterialise(context context.Context, templateID int, task *models.Task, created time.Time) (int, error) {
+	var taskID int
+	err := s.With(func(tx *sql.Tx) error {
+		var err error
+		if taskID, err = insertTask(tx, task); err != nil {
+			return err
+		}
+		_, err = tx.Exec("UPDATE template SET last_created = $1 WHERE id = $2", created, &templateID)
+		return err
+	})
+	return taskID, err
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+// TaskRestoreByID takes the task out of the trash,
+// models.ErrNotFound when it is not in the trash
+func (s SqliteStore) TaskRestoreByID(context context.Context, taskID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		result, err := tx.Exec("UPDATE task SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL", time.Now(), &taskID)
+		if err != nil {
+			return err
+		}
+		return notFound(result)
+	})
+}
+
+// TrashList returns the tasks in the trash, last deleted first
+func (s SqliteStore) TrashList(context context.Context) (models.List, error) {
+	tasks := models.List{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT ` + taskColumns + ` FROM task WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			task := models.Task{}
+			if err := scanTask(rows, &task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		if tasks[i].Pomodoros, err = s.PomodoroGetByTaskID(context, tasks[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// purgeStatements delete a task with everything recorded about it
+var purgeStatements = []string{
+	"DELETE FROM task WHERE id = $1",
+	"DELETE FROM pomodoro WHERE task_id = $1",
+	"DELETE FROM note WHERE task_id = $1",
+}
+
+// TrashPurge permanently deletes the tasks moved to the trash
+// before the given time with their pomodoros and notes,
+// it returns the IDs of the deleted tasks
+func (s SqliteStore) TrashPurge(context context.Context, before time.Time) ([]int, error) {
+	trash, err := s.TrashList(context)
+	if err != nil {
+		return nil, err
+	}
+	purged := []int{}
+	err = s.With(func(tx *sql.Tx) error {
+		for _, task := range trash {
+			if !task.DeletedAt.Before(before) {
+				continue
+			}
+			if err := bury(tx, "task", "id = $2", task.ID); err != nil {
+				return err
+			}
+			if err := bury(tx, "pomodoro", "task_id = $2", task.ID); err != nil {
+				return err
+			}
+			for _, stmt := range purgeStatements {
+				if _, err := tx.Exec(stmt, task.ID); err != nil {
+					return err
+				}
+			}
+			purged = append(purged, task.ID)
+		}
+		return nil
+	})
+	return purged, err
+}
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/joaorufino/pomo/pkg/core/models"
+)
+
+func (s SqliteStore) WebhookDeliverySave(context context.Context, delivery *models.WebhookDelivery) (int, error) {
+	var deliveryID int
+
+	event, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return -1, err
+	}
+	err = s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`INSERT INTO webhook_delivery (endpoint,event,attempts,next_attempt,last_error,failed) VALUES ($1,$2,$3,$4,$5,$6)`,
+			delivery.Endpoint,
+			string(event),
+			delivery.Attempts,
+			delivery.NextAttempt,
+			delivery.LastError,
+			delivery.Failed,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.QueryRow("SELECT last_insert_rowid() FROM webhook_delivery").Scan(&deliveryID)
+	})
+	return deliveryID, err
+}
+
+func (s SqliteStore) WebhookDeliveryUpdate(context context.Context, delivery *models.WebhookDelivery) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			`UPDATE webhook_delivery SET attempts = $1, next_attempt = $2, last_error = $3, failed = $4 WHERE id = $5`,
+			delivery.Attempts,
+			delivery.NextAttempt,
+			delivery.LastError,
+			delivery.Failed,
+			delivery.ID,
+		)
+		return err
+	})
+}
+
+func (s SqliteStore) WebhookDeliveryDeleteByID(context context.Context, deliveryID int) error {
+	return s.With(func(tx *sql.Tx) error {
+		_, err := tx.Exec("DELETE FROM webhook_delivery WHERE id = $1", &deliveryID)
+		return err
+	})
+}
+
+// WebhookDeliveryPurge deletes the deliveries that failed
+// before the given time and returns how many were deleted
+func (s SqliteStore) WebhookDeliveryPurge(context context.Context, before time.Time) (int, error) {
+	var purged int64
+	err := s.With(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM webhook_delivery WHERE failed AND julianday(next_attempt) < julianday($1)`, before)
+		if err != nil {
+			return err
+		}
+		purged, err = result.RowsAffected()
+		return err
+	})
+	return int(purged), err
+}
+
+func (s SqliteStore) WebhookDeliveryList(context context.Context) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+	err := s.With(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`SELECT id,endpoint,event,attempts,next_attempt,last_error,failed FROM webhook_delivery ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var event string
+			delivery := &models.WebhookDelivery{}
+			err = rows.Scan(
+				&delivery.ID,
+				&delivery.Endpoint,
+				&event,
+				&delivery.Attempts,
+				&delivery.NextAttempt,
+				&delivery.LastError,
+				&delivery.Failed,
+			)
+			if err != nil {
+				return err
+			}
+			if err = json.Unmarshal([]byte(event), &delivery.Event); err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+		return rows.Err()
+	})
+	return deliveries, err
+}
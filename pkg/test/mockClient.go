@@ -1,6 +1,9 @@
 package test
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/joaorufino/pomo/pkg/core"
 	"github.com/joaorufino/pomo/pkg/core/models"
 	"github.com/knadh/koanf"
@@ -9,18 +12,34 @@ import (
 type MockClient struct {
 	config  *koanf.Koanf
 	options MockClientOptions
+	// guards the controls, sent by the tests
+	// and polled by the runner
+	mu sync.Mutex
 }
 
 type MockClientOptions struct {
-	List   *models.List
-	status *models.Status
-	taskID int
+	List      *models.List
+	status    *models.Status
+	taskID    int
+	controls  []models.Control
+	Goals     []models.Goal
+	Projects  models.Projects
+	Tags      models.Tags
+	Templates models.Templates
+	Trash     models.List
+	Queue     []models.QueuedRequest
 }
 
 func NewMockClient(k *koanf.Koanf, options MockClientOptions) core.Client {
 	client := MockClient{}
 	client.SetServerStatus(&models.Status{})
 	client.SetList(&models.List{})
+	client.options.Goals = options.Goals
+	client.options.Projects = options.Projects
+	client.options.Tags = options.Tags
+	client.options.Templates = options.Templates
+	client.options.Trash = options.Trash
+	client.options.Queue = options.Queue
 	if options.List != nil {
 		client.options.List = options.List
 
@@ -48,8 +67,46 @@ func (c *MockClient) Close() error {
 	return nil
 }
 func (c *MockClient) DeleteTaskByID(taskID int) error {
+	for i, task := range *c.options.List {
+		if task.ID == taskID {
+			*c.options.List = append((*c.options.List)[:i], (*c.options.List)[i+1:]...)
+			c.options.Trash = append(c.options.Trash, task)
+			return nil
+		}
+	}
+	return models.NewError(models.ErrorTypeNotFound, "task %d does not exist", taskID)
+}
+
+func (c *MockClient) RestoreTaskByID(taskID int) error {
+	for i, task := range c.options.Trash {
+		if task.ID == taskID {
+			c.options.Trash = append(c.options.Trash[:i], c.options.Trash[i+1:]...)
+			*c.options.List = append(*c.options.List, task)
+			return nil
+		}
+	}
+	return fmt.Errorf("task %d is not in the trash", taskID)
+}
+
+func (c *MockClient) GetTrash() (*models.List, error) {
+	return &c.options.Trash, nil
+}
+
+func (c *MockClient) EmptyTrash() error {
+	c.options.Trash = nil
 	return nil
 }
+func (c *MockClient) GetQueue() ([]models.QueuedRequest, error) {
+	return c.options.Queue, nil
+}
+func (c *MockClient) FlushQueue() (int, error) {
+	sent := len(c.options.Queue)
+	c.options.Queue = nil
+	return sent, nil
+}
+func (c *MockClient) Sync(remote string) (*models.SyncReport, error) {
+	return &models.SyncReport{}, nil
+}
 func (c *MockClient) GetServerStatus() (*models.Status, error) {
 	return c.options.status, nil
 }
@@ -61,12 +118,26 @@ func (c *MockClient) GetTaskList() (*models.List, error) {
 	return c.options.List, nil
 }
 
+func (c *MockClient) GetTask(taskID int) (*models.Task, error) {
+	for _, task := range *c.options.List {
+		if task.ID == taskID {
+			return &task, nil
+		}
+	}
+	return nil, models.NewError(models.ErrorTypeNotFound, "task %d does not exist", taskID)
+}
+
 func (c *MockClient) SetList(List *models.List) {
 	c.options.List = List
 }
 
-func (c *MockClient) StartTask(taskID int) error {
-	return nil
+func (c *MockClient) StartTask(taskID int, options models.RunOptions) error {
+	for _, task := range *c.options.List {
+		if task.ID == taskID {
+			return nil
+		}
+	}
+	return models.NewError(models.ErrorTypeNotFound, "task %d does not exist", taskID)
 }
 func (c *MockClient) UpdateStatus(status *models.Status) error {
 	return nil
@@ -81,3 +152,187 @@ func (c *MockClient) SetConfig(k *koanf.Koanf) {
 func (c *MockClient) CreatePomodoro(taskID int, pomodoro models.Pomodoro) error {
 	return nil
 }
+
+// pomodoro returns the listed pomodoro and the index of its task
+func (c *MockClient) pomodoro(pomodoroID int) (int, int, error) {
+	for i, task := range *c.options.List {
+		for j, pomodoro := range task.Pomodoros {
+			if pomodoro.ID == pomodoroID {
+				return i, j, nil
+			}
+		}
+	}
+	return -1, -1, fmt.Errorf("pomodoro %d does not exist", pomodoroID)
+}
+
+func (c *MockClient) GetPomodoros(query models.PomodoroQuery) ([]*models.Pomodoro, error) {
+	pomodoros := []*models.Pomodoro{}
+	for _, task := range *c.options.List {
+		for _, pomodoro := range task.Pomodoros {
+			if query.Match(*pomodoro) {
+				pomodoros = append(pomodoros, pomodoro)
+			}
+		}
+	}
+	return pomodoros, nil
+}
+
+func (c *MockClient) GetPomodoro(pomodoroID int) (*models.Pomodoro, error) {
+	i, j, err := c.pomodoro(pomodoroID)
+	if err != nil {
+		return nil, err
+	}
+	pomodoro := *(*c.options.List)[i].Pomodoros[j]
+	return &pomodoro, nil
+}
+
+func (c *MockClient) UpdatePomodoro(pomodoro models.Pomodoro) error {
+	if err := pomodoro.Validate(); err != nil {
+		return err
+	}
+	i, j, err := c.pomodoro(pomodoro.ID)
+	if err != nil {
+		return err
+	}
+	(*c.options.List)[i].Pomodoros[j] = &pomodoro
+	return nil
+}
+
+func (c *MockClient) DeletePomodoroByID(pomodoroID int) error {
+	i, j, err := c.pomodoro(pomodoroID)
+	if err != nil {
+		return err
+	}
+	task := &(*c.options.List)[i]
+	task.Pomodoros = append(task.Pomodoros[:j], task.Pomodoros[j+1:]...)
+	return nil
+}
+
+func (c *MockClient) SendControl(control models.Control) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.options.controls = append(c.options.controls, control)
+	return nil
+}
+
+func (c *MockClient) GetControl() (*models.Control, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.options.controls) == 0 {
+		return nil, nil
+	}
+	control := c.options.controls[0]
+	c.options.controls = c.options.controls[1:]
+	return &control, nil
+}
+
+func (c *MockClient) CreateGoal(goal *models.Goal) (int, error) {
+	goal.ID = len(c.options.Goals) + 1
+	c.options.Goals = append(c.options.Goals, *goal)
+	return goal.ID, nil
+}
+
+func (c *MockClient) GetGoals() ([]models.Goal, error) {
+	return c.options.Goals, nil
+}
+
+func (c *MockClient) DeleteGoalByID(goalID int) error {
+	for i, goal := range c.options.Goals {
+		if goal.ID == goalID {
+			c.options.Goals = append(c.options.Goals[:i], c.options.Goals[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *MockClient) CreateProject(project *models.Project) (int, error) {
+	if err := project.Validate(c.options.Projects); err != nil {
+		return -1, err
+	}
+	project.ID = len(c.options.Projects) + 1
+	c.options.Projects = append(c.options.Projects, *project)
+	return project.ID, nil
+}
+
+func (c *MockClient) GetProjects() (models.Projects, error) {
+	return c.options.Projects, nil
+}
+
+func (c *MockClient) ArchiveProject(projectID int) error {
+	for id := range c.options.Projects.Subtree(projectID) {
+		if project := c.options.Projects.ByID(id); project != nil {
+			project.Archived = true
+		}
+	}
+	return nil
+}
+
+func (c *MockClient) GetTags() (models.Tags, error) {
+	return c.options.Tags, nil
+}
+
+func (c *MockClient) SetTagColor(tag models.Tag) error {
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	if known := c.options.Tags.ByName(tag.Name); known != nil {
+		known.Color = tag.Color
+		return nil
+	}
+	c.options.Tags = append(c.options.Tags, tag)
+	return nil
+}
+
+func (c *MockClient) RenameTags(rename models.TagRename) error {
+	if err := rename.Validate(); err != nil {
+		return err
+	}
+	for i := range *c.options.List {
+		task := &(*c.options.List)[i]
+		task.Tags = rename.Apply(task.Tags)
+	}
+	return nil
+}
+
+func (c *MockClient) CreateTemplate(template *models.Template) (int, error) {
+	if err := template.Validate(c.options.Templates); err != nil {
+		return -1, err
+	}
+	template.ID = len(c.options.Templates) + 1
+	c.options.Templates = append(c.options.Templates, *template)
+	return template.ID, nil
+}
+
+func (c *MockClient) GetTemplates() (models.Templates, error) {
+	return c.options.Templates, nil
+}
+
+func (c *MockClient) DeleteTemplateByID(templateID int) error {
+	for i, template := range c.options.Templates {
+		if template.ID == templateID {
+			c.options.Templates = append(c.options.Templates[:i], c.options.Templates[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *MockClient) AddNote(taskID int, note models.Note) error {
+	for i := range *c.options.List {
+		task := &(*c.options.List)[i]
+		if task.ID != taskID {
+			continue
+		}
+		if err := note.Validate(task); err != nil {
+			return err
+		}
+		if note.Pomodoro > 0 {
+			task.Pomodoros[note.Pomodoro-1].Note = note.Text
+			return nil
+		}
+		task.Notes = append(task.Notes, note)
+		return nil
+	}
+	return fmt.Errorf("task %d does not exist", taskID)
+}